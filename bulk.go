@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"l8zykube/components"
+	"l8zykube/kubernetes"
+	"strconv"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bulkParallelism caps how many objects a bulk action changes at once
+const bulkParallelism = 5
+
+// bulkConfirmListLimit caps how many targets a bulk confirmation lists
+const bulkConfirmListLimit = 10
+
+type bulkFinishedMsg struct {
+	action  string
+	results []components.Result
+}
+
+// bulkTarget names a bulk action target, e.g. "pods default/nginx"
+func bulkTarget(res kubernetes.ResourceInfo) string {
+	target := res.Name
+	if ns := strings.TrimSpace(res.Namespace); ns != "" {
+		target = ns + "/" + res.Name
+	}
+	return normalizeResourceTypeForFetch(res.Type) + " " + target
+}
+
+// runBulkCmd applies fn to every target, at most bulkParallelism at a time.
+// Every call gets its own timeout, so one slow object does not hold up the
+// rest; the results keep the order of targets.
+func runBulkCmd(action string, targets []kubernetes.ResourceInfo, fn func(ctx context.Context, res kubernetes.ResourceInfo) error) tea.Cmd {
+	return func() tea.Msg {
+		results := make([]components.Result, len(targets))
+		sem := make(chan struct{}, bulkParallelism)
+		var wg sync.WaitGroup
+		for i, res := range targets {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int, res kubernetes.ResourceInfo) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+				defer cancel()
+				results[i] = components.Result{Target: bulkTarget(res), Err: fn(ctx, res)}
+			}(i, res)
+		}
+		wg.Wait()
+		return bulkFinishedMsg{action: action, results: results}
+	}
+}
+
+func bulkMenuItems() []components.MenuItem {
+	return []components.MenuItem{
+		{Label: "Delete", Description: "Delete every target", Value: "delete"},
+		{Label: "Restart", Description: "Roll out new pods (deployments, statefulsets, daemonsets)", Value: "restart"},
+		{Label: "Label", Description: "Set or remove labels: key=value,other-", Value: "label"},
+		{Label: "Annotate", Description: "Set or remove annotations: key=value,other-", Value: "annotate"},
+		{Label: "Scale", Description: "Set the replica count", Value: "scale"},
+	}
+}
+
+// bulkConfirmMessage asks to confirm an action and lists the first targets
+func bulkConfirmMessage(verb string, targets []kubernetes.ResourceInfo) string {
+	lines := []string{fmt.Sprintf("%s %d resources?", verb, len(targets)), ""}
+	for i, res := range targets {
+		if i == bulkConfirmListLimit {
+			lines = append(lines, fmt.Sprintf("... and %d more", len(targets)-i))
+			break
+		}
+		lines = append(lines, bulkTarget(res))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func bulkDeleteCmd(client *kubernetes.KubeClient, targets []kubernetes.ResourceInfo) tea.Cmd {
+	return runBulkCmd("Delete", targets, func(ctx context.Context, res kubernetes.ResourceInfo) error {
+		return client.DeleteResource(ctx, normalizeResourceTypeForFetch(res.Type), res.Namespace, res.Name, kubernetes.DeleteOptions{})
+	})
+}
+
+func bulkRestartCmd(client *kubernetes.KubeClient, targets []kubernetes.ResourceInfo) tea.Cmd {
+	return runBulkCmd("Restart", targets, func(ctx context.Context, res kubernetes.ResourceInfo) error {
+		return client.RestartResource(ctx, normalizeResourceTypeForFetch(res.Type), res.Namespace, res.Name)
+	})
+}
+
+func bulkLabelCmd(client *kubernetes.KubeClient, targets []kubernetes.ResourceInfo, changes map[string]*string) tea.Cmd {
+	return runBulkCmd("Label", targets, func(ctx context.Context, res kubernetes.ResourceInfo) error {
+		return client.UpdateLabels(ctx, normalizeResourceTypeForFetch(res.Type), res.Namespace, res.Name, changes)
+	})
+}
+
+func bulkAnnotateCmd(client *kubernetes.KubeClient, targets []kubernetes.ResourceInfo, changes map[string]*string) tea.Cmd {
+	return runBulkCmd("Annotate", targets, func(ctx context.Context, res kubernetes.ResourceInfo) error {
+		return client.UpdateAnnotations(ctx, normalizeResourceTypeForFetch(res.Type), res.Namespace, res.Name, changes)
+	})
+}
+
+func bulkScaleCmd(client *kubernetes.KubeClient, targets []kubernetes.ResourceInfo, replicas int32) tea.Cmd {
+	return runBulkCmd("Scale", targets, func(ctx context.Context, res kubernetes.ResourceInfo) error {
+		return client.ScaleResource(ctx, normalizeResourceTypeForFetch(res.Type), res.Namespace, res.Name, replicas)
+	})
+}
+
+// parseReplicas parses a replica count typed into a prompt
+func parseReplicas(value string) (int32, error) {
+	replicas, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil || replicas < 0 {
+		return 0, fmt.Errorf("invalid replica count %q, expected a number of 0 or more", value)
+	}
+	return int32(replicas), nil
+}
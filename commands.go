@@ -0,0 +1,433 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"l8zykube/kubernetes"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// requestTimeout bounds every API call made on behalf of the UI
+const requestTimeout = 30 * time.Second
+
+// requestSlot identifies a kind of request. Only the latest request in a slot
+// is applied; starting a new one cancels the previous one.
+type requestSlot int
+
+const (
+	requestAPIResources requestSlot = iota
+	requestNamespaces
+	requestResources
+	requestLogs
+	requestDescribe
+	requestScale
+	requestRollout
+	requestExec
+	requestForward
+	requestEvents
+)
+
+// requestTracker hands out per-request contexts and remembers which request is
+// current in every slot, so results of cancelled or superseded requests can be
+// dropped when they arrive.
+type requestTracker struct {
+	nextID  int
+	active  map[requestSlot]int
+	cancels map[requestSlot]context.CancelFunc
+}
+
+func newRequestTracker() *requestTracker {
+	return &requestTracker{
+		active:  make(map[requestSlot]int),
+		cancels: make(map[requestSlot]context.CancelFunc),
+	}
+}
+
+// Start cancels the request running in slot and returns the context and id of
+// a new one.
+func (t *requestTracker) Start(slot requestSlot) (context.Context, int) {
+	t.Cancel(slot)
+	t.nextID++
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	t.active[slot] = t.nextID
+	t.cancels[slot] = cancel
+	return ctx, t.nextID
+}
+
+// Finish releases the request and reports whether it is still the current one
+// in its slot, i.e. whether its result should be applied.
+func (t *requestTracker) Finish(slot requestSlot, id int) bool {
+	if current, ok := t.active[slot]; !ok || current != id {
+		return false
+	}
+	t.Cancel(slot)
+	return true
+}
+
+func (t *requestTracker) Cancel(slot requestSlot) {
+	if cancel, ok := t.cancels[slot]; ok {
+		cancel()
+	}
+	delete(t.cancels, slot)
+	delete(t.active, slot)
+}
+
+func (t *requestTracker) CancelAll() {
+	for slot := range t.cancels {
+		t.Cancel(slot)
+	}
+}
+
+func (t *requestTracker) Busy(slot requestSlot) bool {
+	_, ok := t.active[slot]
+	return ok
+}
+
+func (t *requestTracker) Pending() bool {
+	return len(t.active) > 0
+}
+
+type apiResourcesLoadedMsg struct {
+	id        int
+	resources []string
+	err       error
+}
+
+type namespacesLoadedMsg struct {
+	id         int
+	namespaces []string
+	err        error
+}
+
+type resourcesLoadedMsg struct {
+	id           int
+	resourceType string
+	namespace    string
+	selector     kubernetes.Selector
+	refresh      bool
+	resources    []kubernetes.ResourceInfo
+	err          error
+}
+
+type podContainersLoadedMsg struct {
+	id         int
+	resource   kubernetes.ResourceInfo
+	containers []kubernetes.ContainerInfo
+	err        error
+}
+
+type execContainersLoadedMsg struct {
+	id         int
+	resource   kubernetes.ResourceInfo
+	containers []kubernetes.ContainerInfo
+	err        error
+}
+
+type podLogsLoadedMsg struct {
+	id       int
+	resource kubernetes.ResourceInfo
+	logs     string
+	err      error
+}
+
+type logStreamStartedMsg struct {
+	session int
+	lines   <-chan kubernetes.LogLine
+	err     error
+}
+
+type logLinesMsg struct {
+	session int
+	lines   []string
+	err     error
+	stream  <-chan kubernetes.LogLine
+}
+
+type logStreamClosedMsg struct {
+	session int
+}
+
+type replicasLoadedMsg struct {
+	id       int
+	resource kubernetes.ResourceInfo
+	replicas int32
+	err      error
+}
+
+type resourceScaledMsg struct {
+	resource kubernetes.ResourceInfo
+	replicas int32
+	err      error
+}
+
+type describeLoadedMsg struct {
+	id           int
+	resourceType string
+	namespace    string
+	name         string
+	description  string
+	yaml         string
+	err          error
+}
+
+type watchStartedMsg struct {
+	session   int
+	resources []kubernetes.ResourceInfo
+	events    <-chan kubernetes.WatchEvent
+	err       error
+}
+
+type watchEventMsg struct {
+	session int
+	event   kubernetes.WatchEvent
+	events  <-chan kubernetes.WatchEvent
+}
+
+type watchClosedMsg struct {
+	session int
+}
+
+// ageTickMsg re-renders a watched table so its ages keep counting up
+type ageTickMsg struct {
+	session int
+}
+
+type createEditorFinishedMsg struct {
+	path string
+	err  error
+}
+
+type manifestAppliedMsg struct {
+	applied []string
+	err     error
+}
+
+type resourceDeletedMsg struct {
+	resource kubernetes.ResourceInfo
+	err      error
+}
+
+// pendingActions collects commands queued from modal callbacks. The callbacks
+// run while Update is handling a key, so they cannot return a tea.Cmd
+// themselves; Update flushes the queue once the modal closes.
+type pendingActions struct {
+	cmds []tea.Cmd
+}
+
+func (p *pendingActions) Add(cmd tea.Cmd) {
+	p.cmds = append(p.cmds, cmd)
+}
+
+func (p *pendingActions) Flush() tea.Cmd {
+	cmds := p.cmds
+	p.cmds = nil
+	return tea.Batch(cmds...)
+}
+
+func loadAPIResourcesCmd(ctx context.Context, client *kubernetes.KubeClient, id int) tea.Cmd {
+	return func() tea.Msg {
+		resources, err := client.GetAPIResources(ctx)
+		return apiResourcesLoadedMsg{id: id, resources: resources, err: err}
+	}
+}
+
+func loadNamespacesCmd(ctx context.Context, client *kubernetes.KubeClient, id int) tea.Cmd {
+	return func() tea.Msg {
+		namespaces, err := client.GetNamespaces(ctx)
+		return namespacesLoadedMsg{id: id, namespaces: namespaces, err: err}
+	}
+}
+
+func loadResourcesCmd(ctx context.Context, client *kubernetes.KubeClient, id int, resourceType, namespace string, sel kubernetes.Selector, refresh bool) tea.Cmd {
+	return func() tea.Msg {
+		resources, err := client.GetResourceListDetailed(ctx, resourceType, namespace, sel)
+		return resourcesLoadedMsg{
+			id:           id,
+			resourceType: resourceType,
+			namespace:    namespace,
+			selector:     sel,
+			refresh:      refresh,
+			resources:    resources,
+			err:          err,
+		}
+	}
+}
+
+func loadPodContainersCmd(ctx context.Context, client *kubernetes.KubeClient, id int, res kubernetes.ResourceInfo) tea.Cmd {
+	return func() tea.Msg {
+		containers, err := client.GetPodContainers(ctx, res.Namespace, res.Name)
+		return podContainersLoadedMsg{id: id, resource: res, containers: containers, err: err}
+	}
+}
+
+func loadExecContainersCmd(ctx context.Context, client *kubernetes.KubeClient, id int, res kubernetes.ResourceInfo) tea.Cmd {
+	return func() tea.Msg {
+		containers, err := client.GetPodContainers(ctx, res.Namespace, res.Name)
+		return execContainersLoadedMsg{id: id, resource: res, containers: runningContainers(containers), err: err}
+	}
+}
+
+func loadPodLogsCmd(ctx context.Context, client *kubernetes.KubeClient, id int, res kubernetes.ResourceInfo, opts kubernetes.LogOptions) tea.Cmd {
+	return func() tea.Msg {
+		logs, err := client.GetPodLogs(ctx, res.Namespace, res.Name, opts)
+		return podLogsLoadedMsg{id: id, resource: res, logs: logs, err: err}
+	}
+}
+
+// logStreamBatch caps how many buffered lines are handed to Update at once
+const logStreamBatch = 500
+
+func startLogStreamCmd(ctx context.Context, client *kubernetes.KubeClient, session int, res kubernetes.ResourceInfo, opts kubernetes.LogOptions) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := client.StreamPodLogs(ctx, res.Namespace, res.Name, opts)
+		return logStreamStartedMsg{session: session, lines: lines, err: err}
+	}
+}
+
+// waitForLogLines blocks until at least one line arrives, then takes whatever
+// else is already waiting so a chatty container does not cause one re-render
+// per line.
+func waitForLogLines(session int, stream <-chan kubernetes.LogLine) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-stream
+		if !ok {
+			return logStreamClosedMsg{session: session}
+		}
+		if line.Err != nil {
+			return logLinesMsg{session: session, err: line.Err, stream: stream}
+		}
+		lines := []string{line.Text}
+		for len(lines) < logStreamBatch {
+			select {
+			case next, ok := <-stream:
+				if !ok {
+					return logLinesMsg{session: session, lines: lines, stream: stream}
+				}
+				if next.Err != nil {
+					return logLinesMsg{session: session, lines: lines, err: next.Err, stream: stream}
+				}
+				lines = append(lines, next.Text)
+			default:
+				return logLinesMsg{session: session, lines: lines, stream: stream}
+			}
+		}
+		return logLinesMsg{session: session, lines: lines, stream: stream}
+	}
+}
+
+func loadDescribeCmd(ctx context.Context, client *kubernetes.KubeClient, id int, resourceType, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		desc, err := client.DescribeResource(ctx, resourceType, namespace, name)
+		msg := describeLoadedMsg{
+			id:           id,
+			resourceType: resourceType,
+			namespace:    namespace,
+			name:         name,
+			err:          err,
+		}
+		if desc != nil {
+			msg.description, msg.yaml = desc.Text, desc.YAML
+		}
+		return msg
+	}
+}
+
+func loadReplicasCmd(ctx context.Context, client *kubernetes.KubeClient, id int, res kubernetes.ResourceInfo) tea.Cmd {
+	return func() tea.Msg {
+		replicas, err := client.GetReplicas(ctx, normalizeResourceTypeForFetch(res.Type), res.Namespace, res.Name)
+		return replicasLoadedMsg{id: id, resource: res, replicas: replicas, err: err}
+	}
+}
+
+func scaleResourceCmd(client *kubernetes.KubeClient, res kubernetes.ResourceInfo, replicas int32) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		err := client.ScaleResource(ctx, normalizeResourceTypeForFetch(res.Type), res.Namespace, res.Name, replicas)
+		return resourceScaledMsg{resource: res, replicas: replicas, err: err}
+	}
+}
+
+func deleteResourceCmd(client *kubernetes.KubeClient, res kubernetes.ResourceInfo, opts kubernetes.DeleteOptions) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		err := client.DeleteResource(ctx, normalizeResourceTypeForFetch(res.Type), res.Namespace, res.Name, opts)
+		return resourceDeletedMsg{resource: res, err: err}
+	}
+}
+
+func applyManifestCmd(client *kubernetes.KubeClient, manifest []byte, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		applied, err := client.ApplyManifest(ctx, manifest, namespace)
+		return manifestAppliedMsg{applied: applied, err: err}
+	}
+}
+
+func startWatchCmd(ctx context.Context, client *kubernetes.KubeClient, session int, resourceType, namespace string, sel kubernetes.Selector) tea.Cmd {
+	return func() tea.Msg {
+		resources, events, err := client.WatchResources(ctx, resourceType, namespace, sel)
+		return watchStartedMsg{session: session, resources: resources, events: events, err: err}
+	}
+}
+
+// waitForWatchEvent blocks until the next watch event arrives and hands it to
+// Update; Update re-issues it to keep the stream flowing.
+func waitForWatchEvent(session int, events <-chan kubernetes.WatchEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return watchClosedMsg{session: session}
+		}
+		return watchEventMsg{session: session, event: event, events: events}
+	}
+}
+
+// ageTickInterval is how often a watched table re-renders its ages
+const ageTickInterval = time.Second
+
+func ageTickCmd(session int) tea.Cmd {
+	return tea.Tick(ageTickInterval, func(time.Time) tea.Msg {
+		return ageTickMsg{session: session}
+	})
+}
+
+// openEditorCmd writes content to a temp file and opens it in the user's
+// editor, resolved the same way kubectl edit resolves it. done receives the temp
+// file path once the editor exits.
+func openEditorCmd(content string, done func(path string, err error) tea.Msg) (tea.Cmd, error) {
+	editor := strings.Fields(determineKubectlEditor())
+	if len(editor) == 0 {
+		return nil, fmt.Errorf("no editor found; set KUBE_EDITOR, VISUAL or EDITOR")
+	}
+
+	file, err := os.CreateTemp("", "l8zykube-*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %v", err)
+	}
+	path := file.Name()
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write temp file: %v", err)
+	}
+	file.Close()
+
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return tea.Batch(
+		tea.ExitAltScreen,
+		tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return done(path, err)
+		}),
+	), nil
+}
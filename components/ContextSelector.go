@@ -0,0 +1,96 @@
+package components
+
+import (
+	"fmt"
+	kubetypes "l8zykube/kubernetes"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ContextItem struct {
+	title, desc string
+}
+
+func (i ContextItem) Title() string       { return i.title }
+func (i ContextItem) Description() string { return i.desc }
+func (i ContextItem) FilterValue() string { return i.title }
+
+type ContextSelector struct {
+	ContextList     []kubetypes.ContextInfo
+	list            list.Model
+	selectedContext string
+	Width           int
+	Height          int
+}
+
+func NewContextSelector() *ContextSelector {
+	l := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	l.Title = "Select Context"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.Styles.Title = lipgloss.NewStyle().MarginLeft(2)
+	l.Styles.PaginationStyle = list.DefaultStyles().PaginationStyle.MarginLeft(2)
+	l.Styles.HelpStyle = list.DefaultStyles().HelpStyle.MarginLeft(2)
+
+	return &ContextSelector{
+		list: l,
+	}
+}
+
+func (cs *ContextSelector) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	cs.list, cmd = cs.list.Update(msg)
+
+	if selectedItem := cs.list.SelectedItem(); selectedItem != nil {
+		if item, ok := selectedItem.(ContextItem); ok {
+			cs.selectedContext = item.title
+		}
+	}
+
+	return cmd
+}
+
+func (cs *ContextSelector) SetContextList(contexts []kubetypes.ContextInfo) {
+	cs.ContextList = contexts
+
+	items := make([]list.Item, 0, len(contexts))
+	currentIndex := 0
+	for i, ctx := range contexts {
+		desc := fmt.Sprintf("Cluster: %s | User: %s", ctx.Cluster, ctx.User)
+		if ctx.Namespace != "" {
+			desc = fmt.Sprintf("%s | Namespace: %s", desc, ctx.Namespace)
+		}
+		if ctx.Current {
+			desc = "* " + desc
+			currentIndex = i
+		}
+		items = append(items, ContextItem{
+			title: ctx.Name,
+			desc:  desc,
+		})
+	}
+
+	cs.list.SetItems(items)
+	cs.list.Select(currentIndex)
+}
+
+func (cs *ContextSelector) GetSelectedContext() string {
+	if selectedItem := cs.list.SelectedItem(); selectedItem != nil {
+		if item, ok := selectedItem.(ContextItem); ok {
+			return item.title
+		}
+	}
+	return cs.selectedContext
+}
+
+func (cs *ContextSelector) SetDimensions(width, height int) {
+	cs.Width = width
+	cs.Height = height
+	cs.list.SetSize(width-4, height-4)
+}
+
+func (cs *ContextSelector) Render() string {
+	return cs.list.View()
+}
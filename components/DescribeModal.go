@@ -19,11 +19,18 @@ type DescribeModal struct {
 	Height    int
 	Title     string
 	Content   string
+	Notice    string
 	Visible   bool
 	scrollPos int
 	lines     []string
 	mode      DescribeMode
 
+	// The describe output and the object's YAML; Content is whichever of
+	// them is shown
+	description string
+	yaml        string
+	showYAML    bool
+
 	resourceType string
 	resourceName string
 	namespace    string
@@ -41,12 +48,13 @@ func (dm *DescribeModal) SetDimensions(width, height int) {
 	dm.Height = height
 }
 
-func (dm *DescribeModal) Show(title, content, resourceType, namespace, name string) {
+func (dm *DescribeModal) Show(title, description, yaml, resourceType, namespace, name string) {
 	dm.Title = title
-	dm.Content = content
-	dm.lines = strings.Split(content, "\n")
+	dm.description = description
+	dm.yaml = yaml
+	dm.setView(false)
 	dm.Visible = true
-	dm.scrollPos = 0
+	dm.Notice = ""
 	dm.mode = DescribeModeRead
 	dm.resourceType = resourceType
 	dm.resourceName = name
@@ -57,6 +65,11 @@ func (dm *DescribeModal) Hide() {
 	dm.Visible = false
 }
 
+// SetNotice shows a one-off message under the title, e.g. where the output was saved
+func (dm *DescribeModal) SetNotice(notice string) {
+	dm.Notice = notice
+}
+
 func (dm *DescribeModal) Mode() DescribeMode {
 	return dm.mode
 }
@@ -65,13 +78,34 @@ func (dm *DescribeModal) SetMode(mode DescribeMode) {
 	dm.mode = mode
 }
 
-func (dm *DescribeModal) UpdateContent(content string) {
-	dm.Content = content
-	dm.lines = strings.Split(content, "\n")
-	dm.scrollPos = 0
+func (dm *DescribeModal) UpdateContent(description, yaml string) {
+	dm.description = description
+	dm.yaml = yaml
+	dm.setView(dm.showYAML)
 	dm.mode = DescribeModeRead
 }
 
+// ToggleYAML switches between the describe output and the object's YAML
+func (dm *DescribeModal) ToggleYAML() {
+	dm.setView(!dm.showYAML)
+}
+
+// ShowingYAML reports whether the object's YAML is shown rather than the
+// describe output
+func (dm *DescribeModal) ShowingYAML() bool {
+	return dm.showYAML
+}
+
+func (dm *DescribeModal) setView(yaml bool) {
+	dm.showYAML = yaml
+	dm.Content = dm.description
+	if yaml {
+		dm.Content = dm.yaml
+	}
+	dm.lines = strings.Split(dm.Content, "\n")
+	dm.scrollPos = 0
+}
+
 func (dm *DescribeModal) TargetInfo() (string, string, string) {
 	return dm.resourceType, dm.namespace, dm.resourceName
 }
@@ -80,15 +114,16 @@ func (dm *DescribeModal) CanEdit() bool {
 	return strings.TrimSpace(dm.resourceType) != "" && strings.TrimSpace(dm.resourceName) != ""
 }
 
-func (dm *DescribeModal) EditCommandArgs() []string {
+// EditTarget names the object being edited, e.g. "deployments/web -n default"
+func (dm *DescribeModal) EditTarget() string {
 	if !dm.CanEdit() {
-		return nil
+		return ""
 	}
-	args := []string{"kubectl", "edit", dm.resourceType, dm.resourceName}
+	target := dm.resourceType + "/" + dm.resourceName
 	if ns := strings.TrimSpace(dm.namespace); ns != "" {
-		args = append(args, "-n", ns)
+		target += " -n " + ns
 	}
-	return args
+	return target
 }
 
 func (dm *DescribeModal) ScrollUp() {
@@ -141,6 +176,9 @@ func (dm *DescribeModal) ScrollToBottom() {
 
 func (dm *DescribeModal) visibleLineCount() int {
 	height := dm.Height - 10
+	if dm.Notice != "" {
+		height--
+	}
 	if height < 1 {
 		height = 1
 	}
@@ -192,21 +230,29 @@ func (dm *DescribeModal) Render() string {
 		Margin(1, 0, 0, 0).
 		Italic(true)
 
-	modeTitle := fmt.Sprintf("%s [%s mode]", dm.Title, titleLabel)
+	viewLabel := "describe"
+	if dm.showYAML {
+		viewLabel = "yaml"
+	}
+	modeTitle := fmt.Sprintf("%s [%s mode, %s]", dm.Title, titleLabel, viewLabel)
 	title := titleStyle.Render(modeTitle)
+	if dm.Notice != "" {
+		notice := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42")).
+			Width(modalWidth - 4).
+			Render(dm.Notice)
+		title = lipgloss.JoinVertical(lipgloss.Left, title, notice)
+	}
 
 	var body string
 	var scrollInfo string
 	var instruction string
 
 	if dm.mode == DescribeModeWrite {
-		editCommand := strings.Join(dm.EditCommandArgs(), " ")
-		if editCommand == "" {
-			editCommand = "kubectl edit <resource> <name>"
-		}
 		message := []string{
 			"Launching editor...",
-			fmt.Sprintf("Command: %s", editCommand),
+			fmt.Sprintf("Editing: %s", dm.EditTarget()),
+			"Save and close the editor to apply the changes; invalid changes reopen the editor.",
 			"After closing the editor, the describe view will refresh automatically.",
 		}
 		body = contentStyle.Render(strings.Join(message, "\n"))
@@ -248,7 +294,7 @@ func (dm *DescribeModal) Render() string {
 				Width(modalWidth - 4).
 				Render(fmt.Sprintf("Lines %d-%d of %d", start+1, end, len(dm.lines)))
 		}
-		instruction = instructionStyle.Render("↑/↓: scroll | PgUp/PgDown: page | g/G: top/bottom | ctrl+e: edit | esc/q: close")
+		instruction = instructionStyle.Render("↑/↓: scroll | PgUp/PgDown: page | g/G: top/bottom | y: describe/yaml | ctrl+e: edit | ctrl+s: save | esc/q: close")
 	}
 
 	content := lipgloss.JoinVertical(
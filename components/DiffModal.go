@@ -0,0 +1,160 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DiffModal shows a unified diff of a pending change and asks whether to
+// apply it
+type DiffModal struct {
+	Width        int
+	Height       int
+	Title        string
+	Visible      bool
+	lines        []string
+	added        int
+	removed      int
+	scrollOffset int
+}
+
+func NewDiffModal() *DiffModal {
+	return &DiffModal{}
+}
+
+func (dm *DiffModal) SetDimensions(width, height int) {
+	dm.Width = width
+	dm.Height = height
+}
+
+func (dm *DiffModal) Show(title, diff string) {
+	dm.Title = title
+	dm.lines = strings.Split(diff, "\n")
+	dm.added, dm.removed = 0, 0
+	for _, line := range dm.lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			dm.added++
+		case strings.HasPrefix(line, "-"):
+			dm.removed++
+		}
+	}
+	dm.scrollOffset = 0
+	dm.Visible = true
+}
+
+func (dm *DiffModal) Hide() {
+	dm.Visible = false
+}
+
+func (dm *DiffModal) ScrollUp() {
+	if dm.scrollOffset > 0 {
+		dm.scrollOffset--
+	}
+}
+
+func (dm *DiffModal) ScrollDown() {
+	if dm.scrollOffset < dm.maxOffset() {
+		dm.scrollOffset++
+	}
+}
+
+func (dm *DiffModal) PageUp() {
+	dm.scrollOffset -= dm.visibleLineCount()
+	if dm.scrollOffset < 0 {
+		dm.scrollOffset = 0
+	}
+}
+
+func (dm *DiffModal) PageDown() {
+	dm.scrollOffset += dm.visibleLineCount()
+	if dm.scrollOffset > dm.maxOffset() {
+		dm.scrollOffset = dm.maxOffset()
+	}
+}
+
+func (dm *DiffModal) maxOffset() int {
+	if off := len(dm.lines) - dm.visibleLineCount(); off > 0 {
+		return off
+	}
+	return 0
+}
+
+func (dm *DiffModal) visibleLineCount() int {
+	count := dm.Height - 12
+	if count < 5 {
+		count = 5
+	}
+	return count
+}
+
+func (dm *DiffModal) Render() string {
+	if !dm.Visible {
+		return ""
+	}
+
+	modalWidth := dm.Width - 10
+	if modalWidth < 40 {
+		modalWidth = 40
+	}
+	textWidth := modalWidth - 8
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("214")).
+		Padding(1, 2).
+		Width(modalWidth)
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true).
+		Margin(0, 0, 1, 0)
+	addedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	removedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	hunkStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("87"))
+	fileStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250")).Bold(true)
+	contextStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	instructionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Align(lipgloss.Center).
+		Width(modalWidth-4).
+		Margin(1, 0, 0, 0).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("%s (+%d -%d)", dm.Title, dm.added, dm.removed))}
+
+	start := dm.scrollOffset
+	end := start + dm.visibleLineCount()
+	if end > len(dm.lines) {
+		end = len(dm.lines)
+	}
+	for _, line := range dm.lines[start:end] {
+		text := truncateText(line, textWidth)
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines = append(lines, fileStyle.Render(text))
+		case strings.HasPrefix(line, "@@"):
+			lines = append(lines, hunkStyle.Render(text))
+		case strings.HasPrefix(line, "+"):
+			lines = append(lines, addedStyle.Render(text))
+		case strings.HasPrefix(line, "-"):
+			lines = append(lines, removedStyle.Render(text))
+		default:
+			lines = append(lines, contextStyle.Render(text))
+		}
+	}
+
+	if len(dm.lines) > end-start {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Align(lipgloss.Right).
+			Width(modalWidth-4).
+			Render(fmt.Sprintf("%d-%d of %d", start+1, end, len(dm.lines))))
+	}
+
+	lines = append(lines, instructionStyle.Render("y/enter: apply | e: edit again | n/esc: discard | j/k, pgup/pgdown: scroll"))
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,266 @@
+package components
+
+import (
+	"fmt"
+	kubetypes "l8zykube/kubernetes"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// EventsPanel lists the events of a namespace, most recent first, and can be
+// narrowed down to warnings
+type EventsPanel struct {
+	Width         int
+	Height        int
+	Visible       bool
+	Namespace     string
+	WarningsOnly  bool
+	SelectedIndex int
+	scrollOffset  int
+	all           []kubetypes.ClusterEvent
+	events        []kubetypes.ClusterEvent
+}
+
+func NewEventsPanel() *EventsPanel {
+	return &EventsPanel{}
+}
+
+func (ep *EventsPanel) SetDimensions(width, height int) {
+	ep.Width = width
+	ep.Height = height
+}
+
+// Show lists events of namespace; "" stands for all namespaces
+func (ep *EventsPanel) Show(namespace string, events []kubetypes.ClusterEvent) {
+	ep.Namespace = namespace
+	ep.SelectedIndex = 0
+	ep.scrollOffset = 0
+	ep.SetEvents(events)
+	ep.Visible = true
+}
+
+// SetEvents replaces the listed events, keeping the cursor in range
+func (ep *EventsPanel) SetEvents(events []kubetypes.ClusterEvent) {
+	ep.all = events
+	ep.filter()
+}
+
+func (ep *EventsPanel) Hide() {
+	ep.Visible = false
+}
+
+// ToggleWarnings switches between all events and warnings only
+func (ep *EventsPanel) ToggleWarnings() {
+	ep.WarningsOnly = !ep.WarningsOnly
+	ep.SelectedIndex = 0
+	ep.scrollOffset = 0
+	ep.filter()
+}
+
+func (ep *EventsPanel) filter() {
+	ep.events = ep.events[:0]
+	for _, e := range ep.all {
+		if !ep.WarningsOnly || e.IsWarning() {
+			ep.events = append(ep.events, e)
+		}
+	}
+	if ep.SelectedIndex >= len(ep.events) {
+		ep.SelectedIndex = len(ep.events) - 1
+	}
+	if ep.SelectedIndex < 0 {
+		ep.SelectedIndex = 0
+	}
+	if ep.scrollOffset > ep.SelectedIndex {
+		ep.scrollOffset = ep.SelectedIndex
+	}
+}
+
+func (ep *EventsPanel) MoveUp() {
+	if ep.SelectedIndex > 0 {
+		ep.SelectedIndex--
+	}
+	if ep.SelectedIndex < ep.scrollOffset {
+		ep.scrollOffset = ep.SelectedIndex
+	}
+}
+
+func (ep *EventsPanel) MoveDown() {
+	if ep.SelectedIndex < len(ep.events)-1 {
+		ep.SelectedIndex++
+	}
+	visible := ep.visibleEventCount()
+	if ep.SelectedIndex >= ep.scrollOffset+visible {
+		ep.scrollOffset = ep.SelectedIndex - visible + 1
+	}
+}
+
+func (ep *EventsPanel) PageUp() {
+	for i := ep.visibleEventCount(); i > 0; i-- {
+		ep.MoveUp()
+	}
+}
+
+func (ep *EventsPanel) PageDown() {
+	for i := ep.visibleEventCount(); i > 0; i-- {
+		ep.MoveDown()
+	}
+}
+
+// Selected returns the event under the cursor, or nil
+func (ep *EventsPanel) Selected() *kubetypes.ClusterEvent {
+	if ep.SelectedIndex < 0 || ep.SelectedIndex >= len(ep.events) {
+		return nil
+	}
+	return &ep.events[ep.SelectedIndex]
+}
+
+func (ep *EventsPanel) visibleEventCount() int {
+	count := ep.Height - 14
+	if count < 5 {
+		count = 5
+	}
+	return count
+}
+
+func (ep *EventsPanel) Render() string {
+	if !ep.Visible {
+		return ""
+	}
+
+	modalWidth := ep.Width - 10
+	if modalWidth < 60 {
+		modalWidth = 60
+	}
+	textWidth := modalWidth - 8
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1, 2).
+		Width(modalWidth)
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Margin(0, 0, 1, 0)
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	selectedStyle := lipgloss.NewStyle().Background(lipgloss.Color("236")).Bold(true)
+	instructionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Align(lipgloss.Center).
+		Width(modalWidth-4).
+		Margin(1, 0, 0, 0).
+		Italic(true)
+
+	scope := ep.Namespace
+	if scope == "" {
+		scope = "all namespaces"
+	}
+	title := fmt.Sprintf("Events in %s (%d)", scope, len(ep.events))
+	if ep.WarningsOnly {
+		title = fmt.Sprintf("Warning events in %s (%d of %d)", scope, len(ep.events), len(ep.all))
+	}
+	lines := []string{titleStyle.Render(title)}
+
+	headers := []string{"LAST SEEN", "TYPE", "REASON", "OBJECT", "COUNT"}
+	if ep.Namespace == "" {
+		headers = append([]string{"NAMESPACE"}, headers...)
+	}
+	widths, messageWidth := eventColumnWidths(textWidth, ep.Namespace == "")
+	row := func(cells []string, message string) string {
+		var b strings.Builder
+		for i, cell := range cells {
+			fmt.Fprintf(&b, "%-*s ", widths[i], truncateText(cell, widths[i]))
+		}
+		b.WriteString(truncateText(message, messageWidth))
+		return b.String()
+	}
+	lines = append(lines, headerStyle.Render(row(headers, "MESSAGE")))
+
+	if len(ep.events) == 0 {
+		empty := "No events"
+		if ep.WarningsOnly {
+			empty = "No warning events; press w to show all"
+		}
+		lines = append(lines, normalStyle.Render(empty))
+	}
+
+	start := ep.scrollOffset
+	end := start + ep.visibleEventCount()
+	if end > len(ep.events) {
+		end = len(ep.events)
+	}
+	for i := start; i < end; i++ {
+		e := ep.events[i]
+		cells := []string{
+			kubetypes.FormatAge(e.LastSeen),
+			e.Type,
+			e.Reason,
+			strings.ToLower(e.Object.Kind) + "/" + e.Object.Name,
+			fmt.Sprint(e.Count),
+		}
+		if ep.Namespace == "" {
+			cells = append([]string{e.Object.Namespace}, cells...)
+		}
+		text := row(cells, e.Message)
+		style := normalStyle
+		if e.IsWarning() {
+			style = warningStyle
+		}
+		if i == ep.SelectedIndex {
+			style = style.Inherit(selectedStyle)
+		}
+		lines = append(lines, style.Render(text))
+	}
+
+	if len(ep.events) > end-start {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Align(lipgloss.Right).
+			Width(modalWidth-4).
+			Render(fmt.Sprintf("%d-%d of %d", start+1, end, len(ep.events))))
+	}
+
+	warnings := "w: warnings only"
+	if ep.WarningsOnly {
+		warnings = "w: all events"
+	}
+	lines = append(lines, instructionStyle.Render("j/k, pgup/pgdown: move | enter: go to object | "+warnings+" | r: refresh | esc: close"))
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// eventColumnWidths sizes the columns before MESSAGE, which takes the rest
+// of textWidth. LAST SEEN, TYPE and COUNT are fixed; NAMESPACE, REASON and
+// OBJECT shrink in proportion to their width until MESSAGE gets a third of
+// the row, so some of the message shows on narrow terminals.
+func eventColumnWidths(textWidth int, allNamespaces bool) (widths []int, messageWidth int) {
+	widths = []int{9, 7, 22, 36, 5}
+	flexible := []int{2, 3}
+	if allNamespaces {
+		widths = append([]int{16}, widths...)
+		flexible = []int{0, 3, 4}
+	}
+	messageWidth = textWidth
+	for _, w := range widths {
+		messageWidth -= w + 1
+	}
+
+	short := textWidth/3 - messageWidth
+	if short <= 0 {
+		return widths, messageWidth
+	}
+	total := 0
+	for _, i := range flexible {
+		total += widths[i]
+	}
+	for _, i := range flexible {
+		// Round up so the cuts add up to at least short, but keep 6 columns
+		cut := minInt((short*widths[i]+total-1)/total, widths[i]-6)
+		widths[i] -= cut
+		messageWidth += cut
+	}
+	return widths, messageWidth
+}
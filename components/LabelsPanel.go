@@ -0,0 +1,182 @@
+package components
+
+import (
+	"fmt"
+	kubetypes "l8zykube/kubernetes"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// labelEntry is one label or annotation listed in the LabelsPanel
+type labelEntry struct {
+	key        string
+	value      string
+	annotation bool
+}
+
+// LabelsPanel lists the labels and annotations of a resource next to the
+// resource table. Labels can be picked to list the objects of the same kind
+// carrying them.
+type LabelsPanel struct {
+	Width         int
+	Height        int
+	Visible       bool
+	Title         string
+	SelectedIndex int
+	scrollOffset  int
+	entries       []labelEntry
+	labelCount    int
+}
+
+func NewLabelsPanel() *LabelsPanel {
+	return &LabelsPanel{}
+}
+
+func (lp *LabelsPanel) SetDimensions(width, height int) {
+	lp.Width = width
+	lp.Height = height
+}
+
+// Show lists the labels, then the annotations, of res, each sorted by key
+func (lp *LabelsPanel) Show(res kubetypes.ResourceInfo) {
+	lp.Title = res.Name
+	lp.entries = lp.entries[:0]
+	for _, key := range sortedKeys(res.Labels) {
+		lp.entries = append(lp.entries, labelEntry{key: key, value: res.Labels[key]})
+	}
+	lp.labelCount = len(lp.entries)
+	for _, key := range sortedKeys(res.Annotations) {
+		lp.entries = append(lp.entries, labelEntry{key: key, value: res.Annotations[key], annotation: true})
+	}
+	lp.SelectedIndex = 0
+	lp.scrollOffset = 0
+	lp.Visible = true
+}
+
+func (lp *LabelsPanel) Hide() {
+	lp.Visible = false
+}
+
+func (lp *LabelsPanel) MoveUp() {
+	if lp.SelectedIndex > 0 {
+		lp.SelectedIndex--
+	}
+	if lp.SelectedIndex < lp.scrollOffset {
+		lp.scrollOffset = lp.SelectedIndex
+	}
+}
+
+func (lp *LabelsPanel) MoveDown() {
+	if lp.SelectedIndex < len(lp.entries)-1 {
+		lp.SelectedIndex++
+	}
+	visible := lp.visibleEntryCount()
+	if lp.SelectedIndex >= lp.scrollOffset+visible {
+		lp.scrollOffset = lp.SelectedIndex - visible + 1
+	}
+}
+
+// SelectedLabel returns the label under the cursor as a "key=value" selector.
+// Annotations cannot be selected on, so ok is false for them.
+func (lp *LabelsPanel) SelectedLabel() (selector string, ok bool) {
+	if lp.SelectedIndex < 0 || lp.SelectedIndex >= len(lp.entries) {
+		return "", false
+	}
+	entry := lp.entries[lp.SelectedIndex]
+	if entry.annotation {
+		return "", false
+	}
+	return entry.key + "=" + entry.value, true
+}
+
+func (lp *LabelsPanel) visibleEntryCount() int {
+	// Title, both section headers and the instructions
+	count := lp.Height - 8
+	if count < 3 {
+		count = 3
+	}
+	return count
+}
+
+func (lp *LabelsPanel) Render() string {
+	if !lp.Visible {
+		return ""
+	}
+
+	textWidth := lp.Width - 4
+	if textWidth < 10 {
+		textWidth = 10
+	}
+
+	panelStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(lipgloss.Color("240")).
+		PaddingLeft(1).
+		Width(lp.Width - 1)
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+	headerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Bold(true)
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("87"))
+	annotationStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("250"))
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("236"))
+	instructionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(truncateText(lp.Title, textWidth))}
+
+	start := lp.scrollOffset
+	end := start + lp.visibleEntryCount()
+	if end > len(lp.entries) {
+		end = len(lp.entries)
+	}
+	if start == 0 {
+		lines = append(lines, headerStyle.Render(fmt.Sprintf("Labels (%d)", lp.labelCount)))
+		if lp.labelCount == 0 {
+			lines = append(lines, annotationStyle.Render("<none>"))
+		}
+	}
+	for i := start; i < end; i++ {
+		entry := lp.entries[i]
+		if i == lp.labelCount && entry.annotation {
+			lines = append(lines, headerStyle.Render(fmt.Sprintf("Annotations (%d)", len(lp.entries)-lp.labelCount)))
+		}
+		// Annotation values such as last-applied-configuration span many lines
+		value := strings.Join(strings.Fields(entry.value), " ")
+		text := truncateText(entry.key+"="+value, textWidth)
+		switch {
+		case i == lp.SelectedIndex:
+			lines = append(lines, selectedStyle.Render(text))
+		case entry.annotation:
+			lines = append(lines, annotationStyle.Render(text))
+		default:
+			lines = append(lines, normalStyle.Render(text))
+		}
+	}
+	if end == len(lp.entries) && len(lp.entries) == lp.labelCount {
+		lines = append(lines, headerStyle.Render("Annotations (0)"))
+	}
+
+	lines = append(lines, "", instructionStyle.Render(truncateText("enter: list this kind by label | esc: close", textWidth)))
+
+	return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
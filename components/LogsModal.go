@@ -2,19 +2,50 @@ package components
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// maxLogLines bounds how many lines a followed log keeps in memory
+const maxLogLines = 20000
+
+// logInputMode is what the input bar at the bottom of the logs is editing
+type logInputMode int
+
+const (
+	logInputNone logInputMode = iota
+	logInputSearch
+	logInputFilter
+)
+
 type LogsModal struct {
 	Width     int
 	Height    int
 	Title     string
+	Status    string
+	Notice    string
 	Logs      string
 	Visible   bool
 	scrollPos int
 	logLines  []string
+	// shown holds the lines that pass the filter; scrolling works on it
+	shown []string
+
+	inputMode logInputMode
+	useRegex  bool
+	inputErr  string
+
+	searchQuery string
+	search      *regexp.Regexp
+	matches     []int
+	matchCursor int
+
+	filterQuery  string
+	filter       *regexp.Regexp
+	filterInvert bool
 }
 
 func NewLogsModal() *LogsModal {
@@ -32,9 +63,15 @@ func (lm *LogsModal) SetDimensions(width, height int) {
 func (lm *LogsModal) Show(title, logs string) {
 	lm.Title = title
 	lm.Logs = logs
-	lm.logLines = strings.Split(logs, "\n")
+	lm.logLines = nil
+	if logs != "" {
+		lm.logLines = strings.Split(strings.TrimSuffix(logs, "\n"), "\n")
+	}
 	lm.Visible = true
 	lm.scrollPos = 0
+	lm.Notice = ""
+	lm.inputMode = logInputNone
+	lm.rebuild()
 
 	// Scroll to bottom of the logs
 	// lm.ScrollToBottom()
@@ -44,6 +81,347 @@ func (lm *LogsModal) Hide() {
 	lm.Visible = false
 }
 
+// SetStatus sets the line shown under the title, e.g. the active log options
+func (lm *LogsModal) SetStatus(status string) {
+	lm.Status = status
+}
+
+// SetNotice shows a one-off message under the status, e.g. where logs were saved
+func (lm *LogsModal) SetNotice(notice string) {
+	lm.Notice = notice
+}
+
+// Content returns the whole log buffer, regardless of the active filter
+func (lm *LogsModal) Content() string {
+	if len(lm.logLines) == 0 {
+		return ""
+	}
+	return strings.Join(lm.logLines, "\n") + "\n"
+}
+
+// LineCount returns the number of log lines currently held
+func (lm *LogsModal) LineCount() int {
+	return len(lm.logLines)
+}
+
+// ClearSearch drops the search and filter, e.g. when another pod is opened
+func (lm *LogsModal) ClearSearch() {
+	lm.inputMode = logInputNone
+	lm.inputErr = ""
+	lm.searchQuery = ""
+	lm.search = nil
+	lm.filterQuery = ""
+	lm.filter = nil
+	lm.filterInvert = false
+	lm.rebuild()
+}
+
+// AppendLines adds streamed lines. Lines are run through the active filter
+// and search, and the view keeps following the end of the log unless the
+// user has scrolled up.
+func (lm *LogsModal) AppendLines(lines []string) {
+	atBottom := lm.scrollPos >= lm.maxScroll()
+	lm.logLines = append(lm.logLines, lines...)
+	if overflow := len(lm.logLines) - maxLogLines; overflow > 0 {
+		lm.logLines = lm.logLines[overflow:]
+	}
+	for _, line := range lines {
+		if lm.passesFilter(line) {
+			if lm.search != nil && lm.search.MatchString(line) {
+				lm.matches = append(lm.matches, len(lm.shown))
+			}
+			lm.shown = append(lm.shown, line)
+		}
+	}
+	if overflow := len(lm.shown) - maxLogLines; overflow > 0 {
+		lm.shown = lm.shown[overflow:]
+		lm.scrollPos -= overflow
+		if lm.scrollPos < 0 {
+			lm.scrollPos = 0
+		}
+		kept := lm.matches[:0]
+		for _, idx := range lm.matches {
+			if idx >= overflow {
+				kept = append(kept, idx-overflow)
+			}
+		}
+		lm.matchCursor -= len(lm.matches) - len(kept)
+		if lm.matchCursor < 0 {
+			lm.matchCursor = 0
+		}
+		lm.matches = kept
+	}
+	if atBottom {
+		lm.ScrollToBottom()
+	}
+}
+
+// HandleKey handles the search and filter keys and reports whether the key
+// was consumed. While the input bar is open it takes every key but ctrl+q.
+func (lm *LogsModal) HandleKey(msg tea.KeyMsg) bool {
+	key := msg.String()
+	if lm.inputMode != logInputNone {
+		switch key {
+		case "ctrl+q":
+			return false
+		case "enter":
+			lm.inputMode = logInputNone
+			lm.inputErr = ""
+		case "esc":
+			if lm.inputMode == logInputSearch {
+				lm.setSearch("")
+			} else {
+				lm.setFilter("")
+			}
+			lm.inputMode = logInputNone
+		case "ctrl+r":
+			lm.toggleRegex()
+		case "backspace", "ctrl+h":
+			query := []rune(lm.inputQuery())
+			if len(query) > 0 {
+				lm.setInputQuery(string(query[:len(query)-1]))
+			}
+		case " ":
+			lm.setInputQuery(lm.inputQuery() + " ")
+		default:
+			if msg.Type == tea.KeyRunes {
+				lm.setInputQuery(lm.inputQuery() + string(msg.Runes))
+			}
+		}
+		return true
+	}
+
+	switch key {
+	case "/":
+		lm.inputMode = logInputSearch
+		lm.setSearch("")
+	case "&":
+		lm.inputMode = logInputFilter
+	case "n":
+		if len(lm.matches) > 0 {
+			lm.matchCursor = (lm.matchCursor + 1) % len(lm.matches)
+			lm.revealMatch()
+		}
+	case "N":
+		if len(lm.matches) > 0 {
+			lm.matchCursor = (lm.matchCursor - 1 + len(lm.matches)) % len(lm.matches)
+			lm.revealMatch()
+		}
+	case "ctrl+r":
+		lm.toggleRegex()
+	default:
+		return false
+	}
+	return true
+}
+
+func (lm *LogsModal) inputQuery() string {
+	if lm.inputMode == logInputFilter {
+		return lm.filterQuery
+	}
+	return lm.searchQuery
+}
+
+func (lm *LogsModal) setInputQuery(query string) {
+	if lm.inputMode == logInputFilter {
+		lm.setFilter(query)
+	} else {
+		lm.setSearch(query)
+	}
+}
+
+func (lm *LogsModal) toggleRegex() {
+	lm.useRegex = !lm.useRegex
+	lm.setFilter(lm.filterQuery)
+	lm.setSearch(lm.searchQuery)
+}
+
+// compileLogPattern turns a query into a matcher. Plain queries match as
+// case-insensitive substrings; regex queries are used as they are.
+func compileLogPattern(query string, useRegex bool) (*regexp.Regexp, error) {
+	if query == "" {
+		return nil, nil
+	}
+	if useRegex {
+		return regexp.Compile(query)
+	}
+	return regexp.Compile("(?i)" + regexp.QuoteMeta(query))
+}
+
+func (lm *LogsModal) setSearch(query string) {
+	lm.searchQuery = query
+	re, err := compileLogPattern(query, lm.useRegex)
+	if err != nil {
+		lm.inputErr = fmt.Sprintf("invalid regex: %v", err)
+		return
+	}
+	lm.inputErr = ""
+	lm.search = re
+	lm.findMatches()
+	// Incremental search jumps to the first match at or below the current view
+	lm.matchCursor = 0
+	for i, idx := range lm.matches {
+		if idx >= lm.scrollPos {
+			lm.matchCursor = i
+			break
+		}
+	}
+	lm.revealMatch()
+}
+
+// setFilter applies a filter query; a leading "!" shows the lines that do
+// not match instead.
+func (lm *LogsModal) setFilter(query string) {
+	lm.filterQuery = query
+	pattern := query
+	invert := strings.HasPrefix(pattern, "!")
+	if invert {
+		pattern = pattern[1:]
+	}
+	re, err := compileLogPattern(pattern, lm.useRegex)
+	if err != nil {
+		lm.inputErr = fmt.Sprintf("invalid regex: %v", err)
+		return
+	}
+	lm.inputErr = ""
+	lm.filter = re
+	lm.filterInvert = invert
+	lm.rebuild()
+}
+
+func (lm *LogsModal) passesFilter(line string) bool {
+	if lm.filter == nil {
+		return true
+	}
+	return lm.filter.MatchString(line) != lm.filterInvert
+}
+
+// rebuild re-applies the filter and search to all held lines
+func (lm *LogsModal) rebuild() {
+	if lm.filter == nil {
+		lm.shown = append([]string(nil), lm.logLines...)
+	} else {
+		lm.shown = lm.shown[:0]
+		for _, line := range lm.logLines {
+			if lm.passesFilter(line) {
+				lm.shown = append(lm.shown, line)
+			}
+		}
+	}
+	lm.findMatches()
+	if lm.scrollPos > lm.maxScroll() {
+		lm.scrollPos = lm.maxScroll()
+	}
+}
+
+func (lm *LogsModal) findMatches() {
+	lm.matches = lm.matches[:0]
+	if lm.search == nil {
+		lm.matchCursor = 0
+		return
+	}
+	for i, line := range lm.shown {
+		if lm.search.MatchString(line) {
+			lm.matches = append(lm.matches, i)
+		}
+	}
+	if lm.matchCursor >= len(lm.matches) {
+		lm.matchCursor = 0
+	}
+}
+
+// revealMatch scrolls so the current match sits in the upper part of the view
+func (lm *LogsModal) revealMatch() {
+	if len(lm.matches) == 0 {
+		return
+	}
+	line := lm.matches[lm.matchCursor]
+	visible := lm.getVisibleLines()
+	if line >= lm.scrollPos && line < lm.scrollPos+visible {
+		return
+	}
+	lm.scrollPos = line - visible/3
+	if lm.scrollPos < 0 {
+		lm.scrollPos = 0
+	}
+	if lm.scrollPos > lm.maxScroll() {
+		lm.scrollPos = lm.maxScroll()
+	}
+}
+
+// highlightLine renders a line with every search match highlighted
+func (lm *LogsModal) highlightLine(line string, current bool) string {
+	if lm.search == nil {
+		return line
+	}
+	locs := lm.search.FindAllStringIndex(line, -1)
+	if len(locs) == 0 {
+		return line
+	}
+	matchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("220"))
+	if current {
+		matchStyle = matchStyle.Background(lipgloss.Color("205"))
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range locs {
+		if loc[1] == loc[0] {
+			continue
+		}
+		b.WriteString(line[last:loc[0]])
+		b.WriteString(matchStyle.Render(line[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+// searchBar renders the input bar, or a summary of the active search/filter
+func (lm *LogsModal) searchBar() string {
+	mode := ""
+	if lm.useRegex {
+		mode = " [regex]"
+	}
+	switch lm.inputMode {
+	case logInputSearch:
+		bar := fmt.Sprintf("/%s_%s", lm.searchQuery, mode)
+		if lm.inputErr != "" {
+			bar += "  " + lm.inputErr
+		}
+		return bar
+	case logInputFilter:
+		bar := fmt.Sprintf("&%s_%s", lm.filterQuery, mode)
+		if lm.inputErr != "" {
+			bar += "  " + lm.inputErr
+		}
+		return bar
+	}
+
+	var parts []string
+	if lm.search != nil {
+		current := 0
+		if len(lm.matches) > 0 {
+			current = lm.matchCursor + 1
+		}
+		parts = append(parts, fmt.Sprintf("search: %s (%d/%d)", lm.searchQuery, current, len(lm.matches)))
+	}
+	if lm.filter != nil {
+		parts = append(parts, fmt.Sprintf("filter: %s (%d of %d lines)", lm.filterQuery, len(lm.shown), len(lm.logLines)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, "  |  ") + mode
+}
+
+func (lm *LogsModal) maxScroll() int {
+	maxScroll := len(lm.shown) - lm.getVisibleLines()
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	return maxScroll
+}
+
 func (lm *LogsModal) ScrollUp() {
 	if lm.scrollPos > 0 {
 		lm.scrollPos--
@@ -51,7 +429,7 @@ func (lm *LogsModal) ScrollUp() {
 }
 
 func (lm *LogsModal) ScrollDown() {
-	maxScroll := len(lm.logLines) - lm.getVisibleLines()
+	maxScroll := len(lm.shown) - lm.getVisibleLines()
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
@@ -70,7 +448,7 @@ func (lm *LogsModal) PageUp() {
 
 func (lm *LogsModal) PageDown() {
 	visibleLines := lm.getVisibleLines()
-	maxScroll := len(lm.logLines) - visibleLines
+	maxScroll := len(lm.shown) - visibleLines
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
@@ -85,7 +463,7 @@ func (lm *LogsModal) ScrollToTop() {
 }
 
 func (lm *LogsModal) ScrollToBottom() {
-	maxScroll := len(lm.logLines) - lm.getVisibleLines()
+	maxScroll := len(lm.shown) - lm.getVisibleLines()
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
@@ -94,6 +472,15 @@ func (lm *LogsModal) ScrollToBottom() {
 
 func (lm *LogsModal) getVisibleLines() int {
 	visibleLines := lm.Height - 10
+	if lm.Status != "" {
+		visibleLines--
+	}
+	if lm.Notice != "" {
+		visibleLines--
+	}
+	if lm.inputMode != logInputNone || lm.search != nil || lm.filter != nil {
+		visibleLines--
+	}
 	if visibleLines < 1 {
 		visibleLines = 1
 	}
@@ -147,6 +534,20 @@ func (lm *LogsModal) Render() string {
 		Italic(true)
 
 	title := titleStyle.Render(lm.Title)
+	if lm.Status != "" {
+		status := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Width(modalWidth - 4).
+			Render(lm.Status)
+		title = lipgloss.JoinVertical(lipgloss.Left, title, status)
+	}
+	if lm.Notice != "" {
+		notice := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42")).
+			Width(modalWidth - 4).
+			Render(lm.Notice)
+		title = lipgloss.JoinVertical(lipgloss.Left, title, notice)
+	}
 
 	// Get visible portion of logs
 	visibleLines := lm.getVisibleLines()
@@ -158,16 +559,16 @@ func (lm *LogsModal) Render() string {
 	if startLine < 0 {
 		startLine = 0
 	}
-	if startLine >= len(lm.logLines) {
-		startLine = len(lm.logLines) - 1
+	if startLine >= len(lm.shown) {
+		startLine = len(lm.shown) - 1
 		if startLine < 0 {
 			startLine = 0
 		}
 	}
 
 	endLine := startLine + visibleLines
-	if endLine > len(lm.logLines) {
-		endLine = len(lm.logLines)
+	if endLine > len(lm.shown) {
+		endLine = len(lm.shown)
 	}
 
 	if startLine > endLine {
@@ -175,9 +576,19 @@ func (lm *LogsModal) Render() string {
 	}
 
 	var visibleLogs string
-	if len(lm.logLines) > 0 && startLine < len(lm.logLines) && endLine <= len(lm.logLines) && startLine <= endLine {
-		// Show all log lines in the visible range
-		visibleLogs = strings.Join(lm.logLines[startLine:endLine], "\n")
+	if len(lm.shown) > 0 && startLine < len(lm.shown) && endLine <= len(lm.shown) && startLine <= endLine {
+		// Show all log lines in the visible range, highlighting search matches
+		currentMatch := -1
+		if len(lm.matches) > 0 {
+			currentMatch = lm.matches[lm.matchCursor]
+		}
+		rendered := make([]string, 0, endLine-startLine)
+		for i := startLine; i < endLine; i++ {
+			rendered = append(rendered, lm.highlightLine(lm.shown[i], i == currentMatch))
+		}
+		visibleLogs = strings.Join(rendered, "\n")
+	} else if lm.filter != nil && len(lm.logLines) > 0 {
+		visibleLogs = "No lines match the filter"
 	} else {
 		visibleLogs = "No logs available"
 	}
@@ -186,23 +597,34 @@ func (lm *LogsModal) Render() string {
 
 	// Show scroll position
 	scrollInfo := ""
-	if len(lm.logLines) > visibleLines {
+	if len(lm.shown) > visibleLines {
 		scrollInfo = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("240")).
 			Align(lipgloss.Right).
 			Width(modalWidth - 4).
-			Render(fmt.Sprintf("Lines %d-%d of %d", startLine+1, endLine, len(lm.logLines)))
+			Render(fmt.Sprintf("Lines %d-%d of %d", startLine+1, endLine, len(lm.shown)))
 	}
 
-	instruction := instructionStyle.Render("↑/↓: Scroll | PgUp/PgDown: Page | Home/End: Top/Bottom | q/ESC: Close")
+	searchBar := ""
+	if bar := lm.searchBar(); bar != "" {
+		searchBar = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214")).
+			Width(modalWidth - 4).
+			Render(bar)
+	}
 
-	content := lipgloss.JoinVertical(
-		lipgloss.Left,
-		title,
-		logsContent,
-		scrollInfo,
-		instruction,
-	)
+	instructionText := "↑/↓ PgUp/PgDown Home/End: Scroll | /: Search | n/N: Next/Prev | &: Filter (!: invert) | ctrl+r: Regex | ctrl+s: Save | q/ESC: Close"
+	if lm.inputMode != logInputNone {
+		instructionText = "enter: Apply | esc: Clear | ctrl+r: Toggle regex"
+	}
+	instruction := instructionStyle.Render(instructionText)
+
+	parts := []string{title, logsContent}
+	if searchBar != "" {
+		parts = append(parts, searchBar)
+	}
+	parts = append(parts, scrollInfo, instruction)
+	content := lipgloss.JoinVertical(lipgloss.Left, parts...)
 
 	return modalStyle.Render(content)
 }
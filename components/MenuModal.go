@@ -0,0 +1,169 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type MenuItem struct {
+	Label       string
+	Description string
+	Value       string
+}
+
+type MenuModal struct {
+	Width         int
+	Height        int
+	Title         string
+	Items         []MenuItem
+	Visible       bool
+	SelectedIndex int
+	scrollOffset  int
+}
+
+func NewMenuModal() *MenuModal {
+	return &MenuModal{
+		Visible: false,
+	}
+}
+
+func (mm *MenuModal) SetDimensions(width, height int) {
+	mm.Width = width
+	mm.Height = height
+}
+
+func (mm *MenuModal) Show(title string, items []MenuItem) {
+	mm.Title = title
+	mm.Items = items
+	mm.Visible = true
+	mm.SelectedIndex = 0
+	mm.scrollOffset = 0
+}
+
+func (mm *MenuModal) Hide() {
+	mm.Visible = false
+}
+
+func (mm *MenuModal) MoveUp() {
+	if mm.SelectedIndex > 0 {
+		mm.SelectedIndex--
+	}
+	if mm.SelectedIndex < mm.scrollOffset {
+		mm.scrollOffset = mm.SelectedIndex
+	}
+}
+
+func (mm *MenuModal) MoveDown() {
+	if mm.SelectedIndex < len(mm.Items)-1 {
+		mm.SelectedIndex++
+	}
+	visible := mm.visibleItemCount()
+	if mm.SelectedIndex >= mm.scrollOffset+visible {
+		mm.scrollOffset = mm.SelectedIndex - visible + 1
+	}
+}
+
+func (mm *MenuModal) Selected() *MenuItem {
+	if mm.SelectedIndex >= 0 && mm.SelectedIndex < len(mm.Items) {
+		return &mm.Items[mm.SelectedIndex]
+	}
+	return nil
+}
+
+func (mm *MenuModal) visibleItemCount() int {
+	count := mm.Height - 14
+	if count < 5 {
+		count = 5
+	}
+	return count
+}
+
+func (mm *MenuModal) Render() string {
+	if !mm.Visible {
+		return ""
+	}
+
+	modalWidth := 60
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("33")).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("33")).
+		Bold(true).
+		Width(modalWidth-4).
+		Margin(0, 0, 1, 0)
+
+	normalStyle := lipgloss.NewStyle().
+		PaddingLeft(2).
+		Foreground(lipgloss.Color("87"))
+	selectedStyle := lipgloss.NewStyle().
+		PaddingLeft(2).
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("236"))
+	descStyle := lipgloss.NewStyle().
+		PaddingLeft(4).
+		Foreground(lipgloss.Color("240"))
+
+	instructionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Align(lipgloss.Center).
+		Width(modalWidth-4).
+		Margin(1, 0, 0, 0).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(mm.Title)}
+
+	start := mm.scrollOffset
+	end := start + mm.visibleItemCount()
+	if end > len(mm.Items) {
+		end = len(mm.Items)
+	}
+	textWidth := modalWidth - 8
+	for i := start; i < end; i++ {
+		item := mm.Items[i]
+		label := truncateText(item.Label, textWidth)
+		if i == mm.SelectedIndex {
+			lines = append(lines, selectedStyle.Render(label))
+		} else {
+			lines = append(lines, normalStyle.Render(label))
+		}
+		if item.Description != "" {
+			lines = append(lines, descStyle.Render(truncateText(item.Description, textWidth)))
+		}
+	}
+	if len(mm.Items) == 0 {
+		lines = append(lines, descStyle.Render("Nothing to choose from"))
+	}
+
+	if len(mm.Items) > end-start {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Align(lipgloss.Right).
+			Width(modalWidth-4).
+			Render(fmt.Sprintf("%d-%d of %d", start+1, end, len(mm.Items))))
+	}
+
+	lines = append(lines, instructionStyle.Render("j/k: move | enter: select | esc: cancel"))
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func truncateText(s string, maxWidth int) string {
+	if maxWidth <= 0 {
+		return ""
+	}
+	r := []rune(s)
+	if len(r) <= maxWidth {
+		return s
+	}
+	if maxWidth == 1 {
+		return "…"
+	}
+	return string(r[:maxWidth-1]) + "…"
+}
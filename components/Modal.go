@@ -45,6 +45,7 @@ func (m *Modal) Show(title, message string) {
 	m.Message = message
 	m.Visible = true
 	m.SelectedBtn = 0
+	m.resetActions()
 }
 
 func (m *Modal) ShowWithType(title, message string, modalType ModalType) {
@@ -53,15 +54,30 @@ func (m *Modal) ShowWithType(title, message string, modalType ModalType) {
 	m.Type = modalType
 	m.Visible = true
 	m.SelectedBtn = 0
+	m.resetActions()
 }
 
 func (m *Modal) ShowWithButtons(title, message string, modalType ModalType, buttons []string) {
 	m.Title = title
 	m.Message = message
 	m.Type = modalType
-	m.Buttons = buttons
 	m.Visible = true
 	m.SelectedBtn = 0
+	m.resetActions()
+	m.Buttons = buttons
+}
+
+// resetActions drops buttons and callbacks left over from a previous dialog,
+// so a plain info modal never re-runs an old confirmation callback.
+func (m *Modal) resetActions() {
+	m.Buttons = []string{"OK"}
+	m.OnConfirm = nil
+	m.OnCancel = nil
+}
+
+// SetMessage replaces the message of the visible modal, keeping its buttons and callbacks
+func (m *Modal) SetMessage(message string) {
+	m.Message = message
 }
 
 func (m *Modal) Hide() {
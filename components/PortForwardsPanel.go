@@ -0,0 +1,178 @@
+package components
+
+import (
+	"fmt"
+	kubetypes "l8zykube/kubernetes"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PortForwardsPanel lists the port-forwards started in this session with
+// their traffic, and lets them be stopped
+type PortForwardsPanel struct {
+	Width         int
+	Height        int
+	Visible       bool
+	Forwards      []*kubetypes.PortForward
+	SelectedIndex int
+	scrollOffset  int
+}
+
+func NewPortForwardsPanel() *PortForwardsPanel {
+	return &PortForwardsPanel{}
+}
+
+func (pp *PortForwardsPanel) SetDimensions(width, height int) {
+	pp.Width = width
+	pp.Height = height
+}
+
+func (pp *PortForwardsPanel) Show(forwards []*kubetypes.PortForward) {
+	pp.SetForwards(forwards)
+	pp.Visible = true
+}
+
+// SetForwards replaces the listed forwards, keeping the cursor in range
+func (pp *PortForwardsPanel) SetForwards(forwards []*kubetypes.PortForward) {
+	pp.Forwards = forwards
+	if pp.SelectedIndex >= len(forwards) {
+		pp.SelectedIndex = len(forwards) - 1
+	}
+	if pp.SelectedIndex < 0 {
+		pp.SelectedIndex = 0
+	}
+	if pp.scrollOffset > pp.SelectedIndex {
+		pp.scrollOffset = pp.SelectedIndex
+	}
+}
+
+func (pp *PortForwardsPanel) Hide() {
+	pp.Visible = false
+}
+
+func (pp *PortForwardsPanel) MoveUp() {
+	if pp.SelectedIndex > 0 {
+		pp.SelectedIndex--
+	}
+	if pp.SelectedIndex < pp.scrollOffset {
+		pp.scrollOffset = pp.SelectedIndex
+	}
+}
+
+func (pp *PortForwardsPanel) MoveDown() {
+	if pp.SelectedIndex < len(pp.Forwards)-1 {
+		pp.SelectedIndex++
+	}
+	visible := pp.visibleForwardCount()
+	if pp.SelectedIndex >= pp.scrollOffset+visible {
+		pp.scrollOffset = pp.SelectedIndex - visible + 1
+	}
+}
+
+// Selected returns the forward under the cursor, or nil
+func (pp *PortForwardsPanel) Selected() *kubetypes.PortForward {
+	if pp.SelectedIndex < 0 || pp.SelectedIndex >= len(pp.Forwards) {
+		return nil
+	}
+	return pp.Forwards[pp.SelectedIndex]
+}
+
+func (pp *PortForwardsPanel) visibleForwardCount() int {
+	// Every forward takes two lines
+	count := (pp.Height - 14) / 2
+	if count < 3 {
+		count = 3
+	}
+	return count
+}
+
+func (pp *PortForwardsPanel) Render() string {
+	if !pp.Visible {
+		return ""
+	}
+
+	modalWidth := 90
+	if pp.Width > 0 && pp.Width-10 < modalWidth {
+		modalWidth = pp.Width - 10
+	}
+	textWidth := modalWidth - 8
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1, 2).
+		Width(modalWidth)
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Margin(0, 0, 1, 0)
+	activeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	failedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	stoppedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	detailStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+	selectedStyle := lipgloss.NewStyle().Background(lipgloss.Color("236")).Bold(true)
+	instructionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Align(lipgloss.Center).
+		Width(modalWidth-4).
+		Margin(1, 0, 0, 0).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("Port Forwards (%d)", len(pp.Forwards)))}
+	if len(pp.Forwards) == 0 {
+		lines = append(lines, stoppedStyle.Render("No port-forwards; press p on a pod, service or deployment to start one"))
+	}
+
+	start := pp.scrollOffset
+	end := start + pp.visibleForwardCount()
+	if end > len(pp.Forwards) {
+		end = len(pp.Forwards)
+	}
+	for i := start; i < end; i++ {
+		pf := pp.Forwards[i]
+		done, err := pf.State()
+		marker, style, state := "●", activeStyle, kubetypes.FormatDuration(time.Since(pf.StartedAt))
+		switch {
+		case done && err != nil:
+			marker, style, state = "✗", failedStyle, "failed: "+err.Error()
+		case done:
+			marker, style, state = "○", stoppedStyle, "stopped"
+		}
+
+		header := fmt.Sprintf("%s localhost:%d → %s:%d", marker, pf.LocalPort, pf.Pod, pf.RemotePort)
+		detail := fmt.Sprintf("  %s  ↓%s ↑%s  %s", pf.Target, formatBytes(pf.BytesIn()), formatBytes(pf.BytesOut()), state)
+		header = style.Render(truncateText(header, textWidth))
+		detail = detailStyle.Render(truncateText(detail, textWidth))
+		if i == pp.SelectedIndex {
+			header = selectedStyle.Render(header)
+		}
+		lines = append(lines, header, detail)
+	}
+
+	if len(pp.Forwards) > end-start {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Align(lipgloss.Right).
+			Width(modalWidth-4).
+			Render(fmt.Sprintf("%d-%d of %d", start+1, end, len(pp.Forwards))))
+	}
+
+	lines = append(lines, instructionStyle.Render("j/k: move | x: stop and remove | esc: close"))
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. "1.5KiB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
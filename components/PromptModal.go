@@ -0,0 +1,108 @@
+package components
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type PromptModal struct {
+	Width   int
+	Height  int
+	Title   string
+	Hint    string
+	Visible bool
+	input   textinput.Model
+}
+
+func NewPromptModal() *PromptModal {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.CharLimit = 512
+
+	return &PromptModal{
+		Visible: false,
+		input:   input,
+	}
+}
+
+func (pm *PromptModal) SetDimensions(width, height int) {
+	pm.Width = width
+	pm.Height = height
+}
+
+// Show opens the prompt with an optional pre-filled value. The cursor is
+// placed at the end of the value so it can be edited or replaced directly.
+func (pm *PromptModal) Show(title, hint, placeholder, value string) {
+	pm.Title = title
+	pm.Hint = hint
+	pm.Visible = true
+	pm.input.Placeholder = placeholder
+	pm.input.SetValue(value)
+	pm.input.CursorEnd()
+	pm.input.Focus()
+}
+
+func (pm *PromptModal) Hide() {
+	pm.Visible = false
+	pm.input.Blur()
+}
+
+func (pm *PromptModal) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	pm.input, cmd = pm.input.Update(msg)
+	return cmd
+}
+
+func (pm *PromptModal) Value() string {
+	return pm.input.Value()
+}
+
+// SetValue replaces the input and moves the cursor to its end
+func (pm *PromptModal) SetValue(value string) {
+	pm.input.SetValue(value)
+	pm.input.CursorEnd()
+}
+
+func (pm *PromptModal) Render() string {
+	if !pm.Visible {
+		return ""
+	}
+
+	modalWidth := 70
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("214")).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true).
+		Width(modalWidth-4).
+		Margin(0, 0, 1, 0)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Width(modalWidth-4).
+		Margin(0, 0, 1, 0)
+
+	instructionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Align(lipgloss.Center).
+		Width(modalWidth-4).
+		Margin(1, 0, 0, 0).
+		Italic(true)
+
+	pm.input.Width = modalWidth - 10
+
+	lines := []string{titleStyle.Render(pm.Title)}
+	if pm.Hint != "" {
+		lines = append(lines, hintStyle.Render(pm.Hint))
+	}
+	lines = append(lines, pm.input.View())
+	lines = append(lines, instructionStyle.Render("enter: confirm | esc: cancel"))
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
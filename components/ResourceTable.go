@@ -3,10 +3,12 @@ package components
 import (
 	"fmt"
 	kubetypes "l8zykube/kubernetes"
+	"sort"
 	"strings"
 	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
 )
 
 type ResourceTable struct {
@@ -16,8 +18,24 @@ type ResourceTable struct {
 	SelectedIndex int
 	Active        bool
 	Watching      bool
+	Status        string
 	Width         int
 	Height        int
+	// SortColumn is the column rows are ordered by; empty keeps API order
+	SortColumn string
+	SortDesc   bool
+	// Filter fuzzy-matches row names; Filtering is set while it is typed
+	Filter    string
+	Filtering bool
+	// ShowLabels adds a LABELS column, like kubectl get --show-labels
+	ShowLabels bool
+	// WatchError is the last error of the running watch, shown until the
+	// watch delivers again
+	WatchError string
+	// all holds every row; Resources is the filtered and sorted view of it
+	all []kubetypes.ResourceInfo
+	// marked holds the rows marked for a bulk action, keyed by resourceKey
+	marked map[string]bool
 }
 
 const (
@@ -34,14 +52,6 @@ type tableColumn struct {
 	width     int
 }
 
-var placeholderValues = map[string]struct{}{
-	"":        {},
-	"<none>":  {},
-	"unknown": {},
-	"n/a":     {},
-	"-":       {},
-}
-
 func NewResourceTable() *ResourceTable {
 	return &ResourceTable{
 		Resources:    []kubetypes.ResourceInfo{},
@@ -52,16 +62,36 @@ func NewResourceTable() *ResourceTable {
 
 func (rt *ResourceTable) SetResources(title string, resources []kubetypes.ResourceInfo) {
 	rt.Title = title
-	rt.Resources = resources
+	rt.Filter = ""
+	rt.Filtering = false
+	rt.all = resources
+	rt.marked = nil
+	rt.Resources = rt.view()
 	rt.ScrollOffset = 0
 	rt.SelectedIndex = 0
 	rt.Active = false
 }
 
+// UpdateResourcesOnly replaces the rows but keeps the sort order and the
+// selection on the same object, falling back to the same index when the
+// object is gone.
 func (rt *ResourceTable) UpdateResourcesOnly(title string, resources []kubetypes.ResourceInfo) {
+	selected := rt.GetSelectedResource()
+	var selectedNamespace, selectedName string
+	if selected != nil {
+		selectedNamespace, selectedName = selected.Namespace, selected.Name
+	}
+
 	rt.Title = title
-	rt.Resources = resources
+	rt.all = resources
+	rt.Resources = rt.view()
+	rt.pruneMarks()
 
+	if selected != nil {
+		if idx := rt.indexOf(selectedNamespace, selectedName); idx >= 0 {
+			rt.SelectedIndex = idx
+		}
+	}
 	if rt.SelectedIndex >= len(rt.Resources) {
 		rt.SelectedIndex = maxInt(len(rt.Resources)-1, 0)
 	}
@@ -74,6 +104,255 @@ func (rt *ResourceTable) UpdateResourcesOnly(title string, resources []kubetypes
 	if rt.ScrollOffset < 0 {
 		rt.ScrollOffset = 0
 	}
+	rt.keepSelectionVisible()
+}
+
+// UpsertResource replaces the row with the same namespace/name, or appends it
+// when the resource is new. Used to patch rows in place from watch events.
+func (rt *ResourceTable) UpsertResource(resource kubetypes.ResourceInfo) {
+	replaced := false
+	for i, res := range rt.all {
+		if res.Name == resource.Name && res.Namespace == resource.Namespace {
+			rt.all[i] = resource
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		rt.all = append(rt.all, resource)
+	}
+	rt.UpdateResourcesOnly(rt.Title, rt.all)
+}
+
+// ToggleSort orders the rows by column. Choosing the current sort column again
+// flips the direction. The selection stays on the same object.
+func (rt *ResourceTable) ToggleSort(column string) {
+	if rt.SortColumn == column {
+		rt.SortDesc = !rt.SortDesc
+	} else {
+		rt.SortColumn = column
+		rt.SortDesc = false
+	}
+	rt.UpdateResourcesOnly(rt.Title, rt.all)
+}
+
+func resourceKey(res kubetypes.ResourceInfo) string {
+	return res.Namespace + "/" + res.Name
+}
+
+// ToggleMark marks or unmarks the selected row and moves to the next one
+func (rt *ResourceTable) ToggleMark() {
+	sel := rt.GetSelectedResource()
+	if sel == nil {
+		return
+	}
+	key := resourceKey(*sel)
+	if rt.marked[key] {
+		delete(rt.marked, key)
+	} else {
+		if rt.marked == nil {
+			rt.marked = make(map[string]bool)
+		}
+		rt.marked[key] = true
+	}
+	rt.ScrollDown()
+}
+
+// ToggleMarkAll marks every row matching the filter, or unmarks them all when
+// they are marked already
+func (rt *ResourceTable) ToggleMarkAll() {
+	allMarked := len(rt.Resources) > 0
+	for _, res := range rt.Resources {
+		if !rt.marked[resourceKey(res)] {
+			allMarked = false
+			break
+		}
+	}
+	if rt.marked == nil {
+		rt.marked = make(map[string]bool)
+	}
+	for _, res := range rt.Resources {
+		if allMarked {
+			delete(rt.marked, resourceKey(res))
+		} else {
+			rt.marked[resourceKey(res)] = true
+		}
+	}
+}
+
+func (rt *ResourceTable) ClearMarks() {
+	rt.marked = nil
+}
+
+// pruneMarks drops the marks of rows that are gone, so len(rt.marked) is the
+// number of marked rows
+func (rt *ResourceTable) pruneMarks() {
+	if len(rt.marked) == 0 {
+		return
+	}
+	present := make(map[string]bool, len(rt.all))
+	for _, res := range rt.all {
+		present[resourceKey(res)] = true
+	}
+	for key := range rt.marked {
+		if !present[key] {
+			delete(rt.marked, key)
+		}
+	}
+}
+
+// MarkedResources returns the marked rows in sort order, including marked
+// rows hidden by the filter
+func (rt *ResourceTable) MarkedResources() []kubetypes.ResourceInfo {
+	rows := append([]kubetypes.ResourceInfo(nil), rt.all...)
+	sortResources(rows, rt.SortColumn, rt.SortDesc)
+	var marked []kubetypes.ResourceInfo
+	for _, res := range rows {
+		if rt.marked[resourceKey(res)] {
+			marked = append(marked, res)
+		}
+	}
+	return marked
+}
+
+// SetFilter fuzzy-filters the rows by name; an empty filter shows every row
+func (rt *ResourceTable) SetFilter(filter string) {
+	rt.Filter = filter
+	rt.UpdateResourcesOnly(rt.Title, rt.all)
+}
+
+// Total returns the number of rows before filtering
+func (rt *ResourceTable) Total() int {
+	return len(rt.all)
+}
+
+// view returns the rows matching Filter, in sort order. Matching rows keep
+// the table order rather than the match score so rows do not jump around
+// while a watch updates them.
+func (rt *ResourceTable) view() []kubetypes.ResourceInfo {
+	rows := make([]kubetypes.ResourceInfo, 0, len(rt.all))
+	if rt.Filter == "" {
+		rows = append(rows, rt.all...)
+	} else {
+		names := make([]string, len(rt.all))
+		for i, res := range rt.all {
+			names[i] = res.Name
+		}
+		matches := fuzzy.Find(rt.Filter, names)
+		matched := make([]bool, len(rt.all))
+		for _, match := range matches {
+			matched[match.Index] = true
+		}
+		for i, res := range rt.all {
+			if matched[i] {
+				rows = append(rows, res)
+			}
+		}
+	}
+	sortResources(rows, rt.SortColumn, rt.SortDesc)
+	return rows
+}
+
+// sortResources orders rows by column, breaking ties by namespace and name so
+// the order is stable across refreshes. An empty column keeps the order.
+func sortResources(rows []kubetypes.ResourceInfo, column string, desc bool) {
+	if column == "" {
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if c := compareColumn(a, b, column); c != 0 {
+			if desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		if c := naturalCompare(a.Namespace, b.Namespace); c != 0 {
+			return c < 0
+		}
+		return naturalCompare(a.Name, b.Name) < 0
+	})
+}
+
+// compareColumn compares two rows by a column. Ages compare by creation time
+// (younger first), everything else naturally so "10" sorts after "9".
+func compareColumn(a, b kubetypes.ResourceInfo, column string) int {
+	if column == "AGE" && !a.CreatedAt.IsZero() && !b.CreatedAt.IsZero() {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	}
+	return naturalCompare(a.Value(column), b.Value(column))
+}
+
+// naturalCompare compares strings case-insensitively, treating runs of digits
+// as numbers.
+func naturalCompare(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			na, restA := splitDigits(a)
+			nb, restB := splitDigits(b)
+			// Compare digit runs by length first, ignoring leading zeros
+			ta, tb := strings.TrimLeft(na, "0"), strings.TrimLeft(nb, "0")
+			if len(ta) != len(tb) {
+				return len(ta) - len(tb)
+			}
+			if c := strings.Compare(ta, tb); c != 0 {
+				return c
+			}
+			a, b = restA, restB
+			continue
+		}
+		if a[0] != b[0] {
+			return int(a[0]) - int(b[0])
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) - len(b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func splitDigits(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// RemoveResource drops the row with the given namespace/name, keeping the
+// selection on the same row where possible.
+func (rt *ResourceTable) RemoveResource(namespace, name string) {
+	for i, res := range rt.all {
+		if res.Name == name && res.Namespace == namespace {
+			rt.all = append(rt.all[:i], rt.all[i+1:]...)
+			rt.UpdateResourcesOnly(rt.Title, rt.all)
+			return
+		}
+	}
+}
+
+// Select moves the selection to the row with the given namespace/name and
+// reports whether it is listed
+func (rt *ResourceTable) Select(namespace, name string) bool {
+	idx := rt.indexOf(namespace, name)
+	if idx < 0 {
+		return false
+	}
+	rt.SelectedIndex = idx
+	rt.keepSelectionVisible()
+	return true
+}
+
+func (rt *ResourceTable) indexOf(namespace, name string) int {
+	for i, res := range rt.Resources {
+		if res.Name == name && res.Namespace == namespace {
+			return i
+		}
+	}
+	return -1
 }
 
 func (rt *ResourceTable) SetActive(active bool) {
@@ -82,6 +361,17 @@ func (rt *ResourceTable) SetActive(active bool) {
 
 func (rt *ResourceTable) SetWatching(watching bool) {
 	rt.Watching = watching
+	rt.WatchError = ""
+}
+
+// SetWatchError shows that the watch failed and is retrying; "" clears it
+func (rt *ResourceTable) SetWatchError(err string) {
+	rt.WatchError = err
+}
+
+// SetStatus sets a short status (e.g. a loading spinner) shown after the title
+func (rt *ResourceTable) SetStatus(status string) {
+	rt.Status = status
 }
 
 func (rt *ResourceTable) GetSelectedResource() *kubetypes.ResourceInfo {
@@ -96,6 +386,20 @@ func (rt *ResourceTable) SetDimensions(width, height int) {
 	rt.Height = height
 }
 
+// keepSelectionVisible scrolls so the selected row is on screen
+func (rt *ResourceTable) keepSelectionVisible() {
+	_, rowsForItems := rt.layoutMetrics()
+	if rt.SelectedIndex < rt.ScrollOffset {
+		rt.ScrollOffset = rt.SelectedIndex
+	}
+	if rt.SelectedIndex >= rt.ScrollOffset+rowsForItems {
+		rt.ScrollOffset = rt.SelectedIndex - rowsForItems + 1
+	}
+	if rt.ScrollOffset < 0 {
+		rt.ScrollOffset = 0
+	}
+}
+
 func (rt *ResourceTable) ScrollUp() {
 	if rt.SelectedIndex > 0 {
 		rt.SelectedIndex--
@@ -175,7 +479,7 @@ func (rt *ResourceTable) PageDown() {
 }
 
 func (rt *ResourceTable) Render() string {
-	if len(rt.Resources) == 0 {
+	if len(rt.all) == 0 {
 		return ""
 	}
 
@@ -188,7 +492,26 @@ func (rt *ResourceTable) Render() string {
 		Foreground(lipgloss.Color("205")).
 		Bold(true).
 		MarginLeft(2).
-		Render(fmt.Sprintf("%s: %s (%d)", titlePrefix, rt.Title, len(rt.Resources)))
+		Render(fmt.Sprintf("%s: %s (%s)", titlePrefix, rt.Title, rt.countLabel()))
+	if rt.Filtering || rt.Filter != "" {
+		cursor := ""
+		if rt.Filtering {
+			cursor = "_"
+		}
+		filter := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214")).
+			Render(fmt.Sprintf("/%s%s", rt.Filter, cursor))
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, "  ", filter)
+	}
+	if rt.Watching && rt.WatchError != "" {
+		watchError := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196")).
+			Render(truncateText("watch error, retrying: "+rt.WatchError, maxInt(rt.Width/2, 20)))
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, "  ", watchError)
+	}
+	if rt.Status != "" {
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, "  ", rt.Status)
+	}
 
 	contentWidth := rt.Width - 4
 	if contentWidth < 20 {
@@ -257,6 +580,7 @@ func (rt *ResourceTable) Render() string {
 	rows = append(rows, title)
 	rows = append(rows, header)
 	rowStyle := lipgloss.NewStyle().PaddingLeft(2)
+	markedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
 
 	// Clamp scroll offset
 	maxOff := maxInt(len(rt.Resources)-rowsForItems, 0)
@@ -281,14 +605,24 @@ func (rt *ResourceTable) Render() string {
 		}
 		line := strings.Join(cells, columnSeparator)
 
+		// Marked rows show a bullet in the left padding
+		marked := rt.marked[resourceKey(r)]
+		if marked {
+			line = "• " + line
+		}
+
 		// Highlight selected row
 		if i == rt.SelectedIndex && rt.Active {
 			selectedStyle := lipgloss.NewStyle().
-				PaddingLeft(2).
 				Foreground(lipgloss.Color("205")).
 				Bold(true).
 				Background(lipgloss.Color("236"))
+			if !marked {
+				selectedStyle = selectedStyle.PaddingLeft(2)
+			}
 			rows = append(rows, selectedStyle.Render(line))
+		} else if marked {
+			rows = append(rows, markedStyle.Render(line))
 		} else {
 			rows = append(rows, rowStyle.Render(line))
 		}
@@ -302,13 +636,26 @@ func (rt *ResourceTable) Render() string {
 	footer := lipgloss.NewStyle().
 		PaddingLeft(2).
 		Foreground(lipgloss.Color("240")).
-		Render(fmt.Sprintf("%d-%d of %d%s", start+1, end, len(rt.Resources), footerHint))
+		Render(fmt.Sprintf("%d-%d of %d%s", minInt(start+1, end), end, len(rt.Resources), footerHint))
 
 	rows = append(rows, footer)
 
 	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
+// countLabel renders the row count, e.g. "12", or "3/12, 2 marked" while
+// filtering with marked rows
+func (rt *ResourceTable) countLabel() string {
+	label := fmt.Sprint(len(rt.Resources))
+	if rt.Filter != "" {
+		label = fmt.Sprintf("%d/%d", len(rt.Resources), len(rt.all))
+	}
+	if marked := len(rt.marked); marked > 0 {
+		label += fmt.Sprintf(", %d marked", marked)
+	}
+	return label
+}
+
 func (rt *ResourceTable) contentHeight() int {
 	if rt.Height <= 0 {
 		return defaultResourceTableHeight
@@ -335,6 +682,13 @@ func (rt *ResourceTable) layoutMetrics() (int, int) {
 }
 
 func (rt *ResourceTable) newColumn(title string, minWidth, maxWidth int, extractor func(kubetypes.ResourceInfo) string) tableColumn {
+	if title == rt.SortColumn {
+		if rt.SortDesc {
+			title += " ▼"
+		} else {
+			title += " ▲"
+		}
+	}
 	minTitleWidth := utf8.RuneCountInString(title)
 	if minWidth < minTitleWidth {
 		minWidth = minTitleWidth
@@ -362,53 +716,23 @@ func (rt *ResourceTable) determineColumns(showNamespace bool) []tableColumn {
 		columns = append(columns, rt.newColumn("NAMESPACE", 12, 24, func(r kubetypes.ResourceInfo) string { return r.Namespace }))
 	}
 
-	if rt.hasMeaningfulValue(func(r kubetypes.ResourceInfo) string { return r.Ready }, "0/0") {
-		columns = append(columns, rt.newColumn("READY", 5, 12, func(r kubetypes.ResourceInfo) string { return r.Ready }))
-	}
-	if rt.hasMeaningfulValue(func(r kubetypes.ResourceInfo) string { return r.Status }) {
-		columns = append(columns, rt.newColumn("STATUS", 8, 24, func(r kubetypes.ResourceInfo) string { return r.Status }))
-	}
-	if rt.hasMeaningfulValue(func(r kubetypes.ResourceInfo) string { return r.Restarts }, "0") {
-		columns = append(columns, rt.newColumn("RESTARTS", 7, 16, func(r kubetypes.ResourceInfo) string { return r.Restarts }))
-	}
-	if rt.hasMeaningfulValue(func(r kubetypes.ResourceInfo) string { return r.Age }) {
-		columns = append(columns, rt.newColumn("AGE", 6, 16, func(r kubetypes.ResourceInfo) string { return r.Age }))
+	// A table only ever lists one resource type, so the first row decides
+	kindColumns := rt.all[0].Columns
+	if kindColumns == nil {
+		kindColumns = kubetypes.ColumnsFor(rt.all[0].Type)
 	}
-	if rt.hasMeaningfulValue(func(r kubetypes.ResourceInfo) string { return r.IP }) {
-		columns = append(columns, rt.newColumn("IP", 8, 24, func(r kubetypes.ResourceInfo) string { return r.IP }))
+	for _, col := range kindColumns {
+		name := col.Name
+		columns = append(columns, rt.newColumn(name, col.MinWidth, col.MaxWidth, func(r kubetypes.ResourceInfo) string { return r.Value(name) }))
 	}
-	if rt.hasMeaningfulValue(func(r kubetypes.ResourceInfo) string { return r.Node }) {
-		columns = append(columns, rt.newColumn("NODE", 8, 24, func(r kubetypes.ResourceInfo) string { return r.Node }))
+	if rt.ShowLabels {
+		col := kubetypes.LabelsColumn
+		columns = append(columns, rt.newColumn(col.Name, col.MinWidth, col.MaxWidth, func(r kubetypes.ResourceInfo) string { return r.Value(col.Name) }))
 	}
 
 	return columns
 }
 
-func (rt *ResourceTable) hasMeaningfulValue(extractor func(kubetypes.ResourceInfo) string, extraIgnores ...string) bool {
-	ignore := make(map[string]struct{}, len(placeholderValues)+len(extraIgnores))
-	for k := range placeholderValues {
-		ignore[k] = struct{}{}
-	}
-	for _, v := range extraIgnores {
-		key := strings.ToLower(strings.TrimSpace(v))
-		if key == "" {
-			continue
-		}
-		ignore[key] = struct{}{}
-	}
-
-	for _, res := range rt.Resources {
-		val := strings.ToLower(strings.TrimSpace(extractor(res)))
-		if val == "" {
-			continue
-		}
-		if _, found := ignore[val]; !found {
-			return true
-		}
-	}
-	return false
-}
-
 func (rt *ResourceTable) layoutColumns(columns []tableColumn, contentWidth int) []tableColumn {
 	if len(columns) == 0 {
 		return columns
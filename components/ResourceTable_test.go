@@ -0,0 +1,93 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	kubetypes "l8zykube/kubernetes"
+)
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"pod", "pod", 0},
+		{"", "pod", -1},
+		{"pod", "pod-1", -1},
+		{"pod-2", "pod-10", -1},
+		{"pod-10", "pod-9", 1},
+		{"pod-10-a", "pod-10-b", -1},
+		{"node-1-b", "node-10-a", -1},
+		{"web-007", "web-7", 0},
+		{"web-007", "web-8", -1},
+		{"9", "10", -1},
+		{"v1.10.0", "v1.9.3", 1},
+		{"99999999999999999999", "100000000000000000000", -1},
+		{"Pod-B", "pod-a", 1},
+		{"POD", "pod", 0},
+		{"api", "API-server", -1},
+		{"a1", "aa", -1},
+	}
+
+	for _, tt := range tests {
+		if got := sign(naturalCompare(tt.a, tt.b)); got != tt.want {
+			t.Errorf("naturalCompare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := sign(naturalCompare(tt.b, tt.a)); got != -tt.want {
+			t.Errorf("naturalCompare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestSortResources(t *testing.T) {
+	now := time.Now()
+	rows := func() []kubetypes.ResourceInfo {
+		return []kubetypes.ResourceInfo{
+			{Namespace: "prod", Name: "web-10", Restarts: "0", CreatedAt: now.Add(-time.Hour)},
+			{Namespace: "dev", Name: "web-2", Restarts: "12", CreatedAt: now.Add(-time.Minute)},
+			{Namespace: "dev", Name: "Web-1", Restarts: "3", CreatedAt: now.Add(-24 * time.Hour)},
+			{Namespace: "dev", Name: "web-10", Restarts: "0", CreatedAt: now.Add(-time.Hour)},
+		}
+	}
+	names := func(rows []kubetypes.ResourceInfo) []string {
+		var out []string
+		for _, r := range rows {
+			out = append(out, r.Namespace+"/"+r.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		column string
+		desc   bool
+		want   []string
+	}{
+		{"", false, []string{"prod/web-10", "dev/web-2", "dev/Web-1", "dev/web-10"}},
+		{"NAME", false, []string{"dev/Web-1", "dev/web-2", "dev/web-10", "prod/web-10"}},
+		{"NAME", true, []string{"dev/web-10", "prod/web-10", "dev/web-2", "dev/Web-1"}},
+		{"RESTARTS", false, []string{"dev/web-10", "prod/web-10", "dev/Web-1", "dev/web-2"}},
+		{"RESTARTS", true, []string{"dev/web-2", "dev/Web-1", "dev/web-10", "prod/web-10"}},
+		{"AGE", false, []string{"dev/web-2", "dev/web-10", "prod/web-10", "dev/Web-1"}},
+	}
+
+	for _, tt := range tests {
+		got := rows()
+		sortResources(got, tt.column, tt.desc)
+		if !reflect.DeepEqual(names(got), tt.want) {
+			t.Errorf("sortResources(%q, desc=%v) = %q, want %q", tt.column, tt.desc, names(got), tt.want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Result is the outcome of an action on a single object
+type Result struct {
+	Target string
+	Err    error
+}
+
+// ResultsModal lists the per-object outcome of a bulk action
+type ResultsModal struct {
+	Width        int
+	Height       int
+	Title        string
+	Results      []Result
+	Visible      bool
+	scrollOffset int
+}
+
+func NewResultsModal() *ResultsModal {
+	return &ResultsModal{}
+}
+
+func (rm *ResultsModal) SetDimensions(width, height int) {
+	rm.Width = width
+	rm.Height = height
+}
+
+// Show lists results, failures first so they are visible without scrolling
+func (rm *ResultsModal) Show(title string, results []Result) {
+	rm.Title = title
+	rm.Results = make([]Result, 0, len(results))
+	for _, r := range results {
+		if r.Err != nil {
+			rm.Results = append(rm.Results, r)
+		}
+	}
+	for _, r := range results {
+		if r.Err == nil {
+			rm.Results = append(rm.Results, r)
+		}
+	}
+	rm.scrollOffset = 0
+	rm.Visible = true
+}
+
+func (rm *ResultsModal) Hide() {
+	rm.Visible = false
+}
+
+func (rm *ResultsModal) Failed() int {
+	failed := 0
+	for _, r := range rm.Results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+func (rm *ResultsModal) ScrollUp() {
+	if rm.scrollOffset > 0 {
+		rm.scrollOffset--
+	}
+}
+
+func (rm *ResultsModal) ScrollDown() {
+	if rm.scrollOffset < rm.maxOffset() {
+		rm.scrollOffset++
+	}
+}
+
+func (rm *ResultsModal) PageUp() {
+	rm.scrollOffset -= rm.visibleLineCount()
+	if rm.scrollOffset < 0 {
+		rm.scrollOffset = 0
+	}
+}
+
+func (rm *ResultsModal) PageDown() {
+	rm.scrollOffset += rm.visibleLineCount()
+	if rm.scrollOffset > rm.maxOffset() {
+		rm.scrollOffset = rm.maxOffset()
+	}
+}
+
+func (rm *ResultsModal) maxOffset() int {
+	if off := len(rm.Results) - rm.visibleLineCount(); off > 0 {
+		return off
+	}
+	return 0
+}
+
+func (rm *ResultsModal) visibleLineCount() int {
+	count := rm.Height - 14
+	if count < 5 {
+		count = 5
+	}
+	return count
+}
+
+func (rm *ResultsModal) Render() string {
+	if !rm.Visible {
+		return ""
+	}
+
+	modalWidth := 80
+	if rm.Width > 0 && rm.Width-10 < modalWidth {
+		modalWidth = rm.Width - 10
+	}
+
+	failed := rm.Failed()
+	borderColor := lipgloss.Color("46")
+	if failed > 0 {
+		borderColor = lipgloss.Color("196")
+	}
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(borderColor).
+		Bold(true).
+		Width(modalWidth-4).
+		Margin(0, 0, 1, 0)
+
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	failStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+	instructionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Align(lipgloss.Center).
+		Width(modalWidth-4).
+		Margin(1, 0, 0, 0).
+		Italic(true)
+
+	summary := fmt.Sprintf("%s: %d succeeded, %d failed", rm.Title, len(rm.Results)-failed, failed)
+	lines := []string{titleStyle.Render(summary)}
+
+	start := rm.scrollOffset
+	end := start + rm.visibleLineCount()
+	if end > len(rm.Results) {
+		end = len(rm.Results)
+	}
+	textWidth := modalWidth - 8
+	for _, r := range rm.Results[start:end] {
+		if r.Err != nil {
+			lines = append(lines, failStyle.Render(truncateText("✗ "+r.Target+": "+r.Err.Error(), textWidth)))
+		} else {
+			lines = append(lines, okStyle.Render(truncateText("✓ "+r.Target, textWidth)))
+		}
+	}
+
+	if len(rm.Results) > end-start {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Align(lipgloss.Right).
+			Width(modalWidth-4).
+			Render(fmt.Sprintf("%d-%d of %d", start+1, end, len(rm.Results))))
+	}
+
+	lines = append(lines, instructionStyle.Render("j/k: scroll | enter/esc: close"))
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"l8zykube/kubernetes"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const editManifestHeader = `# Edit the object below, then save and close the editor to apply it.
+# Lines starting with '#' are ignored and an empty file aborts the edit.
+# If the changes cannot be applied, the editor reopens with the error.
+`
+
+type editObjectLoadedMsg struct {
+	object *kubernetes.EditableObject
+	// edited carries the user's manifest over when the object is reloaded
+	// after a conflict, and failure the error to show above it
+	edited  []byte
+	failure error
+	err     error
+}
+
+type editEditorFinishedMsg struct {
+	path string
+	err  error
+}
+
+type editDryRunMsg struct {
+	edited []byte
+	diff   string
+	err    error
+}
+
+type editAppliedMsg struct {
+	edited  []byte
+	changed bool
+	err     error
+}
+
+func loadEditObjectCmd(client *kubernetes.KubeClient, resourceType, namespace, name string, edited []byte, failure error) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		object, err := client.GetEditableObject(ctx, resourceType, namespace, name)
+		return editObjectLoadedMsg{object: object, edited: edited, failure: failure, err: err}
+	}
+}
+
+func dryRunEditCmd(client *kubernetes.KubeClient, object *kubernetes.EditableObject, edited []byte) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		diff, err := client.DryRunEdit(ctx, object, edited)
+		return editDryRunMsg{edited: edited, diff: diff, err: err}
+	}
+}
+
+func applyEditCmd(client *kubernetes.KubeClient, object *kubernetes.EditableObject, edited []byte) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		changed, err := client.ApplyEdit(ctx, object, edited)
+		return editAppliedMsg{edited: edited, changed: changed, err: err}
+	}
+}
+
+// editManifestContent prepares a manifest for the editor: the instructions,
+// then the error of the previous attempt if any, each line commented out the
+// way kubectl edit shows it
+func editManifestContent(manifest []byte, failure error) string {
+	var b strings.Builder
+	b.WriteString(editManifestHeader)
+	if failure != nil {
+		b.WriteString("#\n")
+		for _, line := range strings.Split(strings.TrimSpace(failure.Error()), "\n") {
+			b.WriteString("# " + line + "\n")
+		}
+	}
+	b.WriteString("#\n")
+	b.WriteString(stripLeadingComments(string(manifest)))
+	return b.String()
+}
+
+// stripLeadingComments removes the comment header of a previous attempt
+func stripLeadingComments(manifest string) string {
+	lines := strings.Split(manifest, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			return strings.Join(lines[i:], "\n")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"l8zykube/kubernetes"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type eventsLoadedMsg struct {
+	id        int
+	namespace string
+	events    []kubernetes.ClusterEvent
+	err       error
+}
+
+// eventObjectResolvedMsg carries the resource an event's object is listed
+// under, to show it in the table
+type eventObjectResolvedMsg struct {
+	id           int
+	object       kubernetes.EventObject
+	resourceType string
+	err          error
+}
+
+func loadEventsCmd(ctx context.Context, client *kubernetes.KubeClient, id int, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		events, err := client.ListEvents(ctx, namespace)
+		return eventsLoadedMsg{id: id, namespace: namespace, events: events, err: err}
+	}
+}
+
+func resolveEventObjectCmd(ctx context.Context, client *kubernetes.KubeClient, id int, object kubernetes.EventObject) tea.Cmd {
+	return func() tea.Msg {
+		resourceType, err := client.ResourceForKind(ctx, object.APIVersion, object.Kind)
+		return eventObjectResolvedMsg{id: id, object: object, resourceType: resourceType, err: err}
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// gzipSuffix marks export paths that are written gzip-compressed
+const gzipSuffix = ".gz"
+
+type exportSavedMsg struct {
+	path string
+	err  error
+}
+
+// exportFileName builds a file name such as "default_nginx_20240102-150405.log"
+// from the object's namespace and name and the current time.
+func exportFileName(namespace, name, ext string, now time.Time) string {
+	parts := make([]string, 0, 3)
+	if ns := strings.TrimSpace(namespace); ns != "" {
+		parts = append(parts, ns)
+	}
+	parts = append(parts, name, now.Format("20060102-150405"))
+	replacer := strings.NewReplacer("/", "-", "\\", "-", " ", "-", ":", "-")
+	return replacer.Replace(strings.Join(parts, "_")) + ext
+}
+
+// toggleGzipSuffix adds or removes the .gz suffix of an export path
+func toggleGzipSuffix(path string) string {
+	if strings.HasSuffix(path, gzipSuffix) {
+		return strings.TrimSuffix(path, gzipSuffix)
+	}
+	return path + gzipSuffix
+}
+
+// writeExport writes content to a new file, gzip-compressed when the path
+// ends in .gz, and returns the absolute path. Existing files are never
+// overwritten.
+func writeExport(path, content string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %s: %v", path, err)
+	}
+
+	file, err := os.OpenFile(absPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %v", absPath, err)
+	}
+
+	var w io.Writer = file
+	var zw *gzip.Writer
+	if strings.HasSuffix(absPath, gzipSuffix) {
+		zw = gzip.NewWriter(file)
+		zw.Name = strings.TrimSuffix(filepath.Base(absPath), gzipSuffix)
+		w = zw
+	}
+
+	if _, err := io.WriteString(w, content); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write %s: %v", absPath, err)
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			file.Close()
+			return "", fmt.Errorf("failed to compress %s: %v", absPath, err)
+		}
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", absPath, err)
+	}
+	return absPath, nil
+}
+
+func saveExportCmd(path, content string) tea.Cmd {
+	return func() tea.Msg {
+		absPath, err := writeExport(path, content)
+		return exportSavedMsg{path: absPath, err: err}
+	}
+}
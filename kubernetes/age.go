@@ -0,0 +1,21 @@
+package kubernetes
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// FormatDuration renders a duration the way kubectl prints ages: "45s",
+// "12m", "3h20m", "5d", "2y".
+func FormatDuration(d time.Duration) string {
+	return duration.HumanDuration(d)
+}
+
+// FormatAge renders how long ago t was. A zero time renders as "<unknown>".
+func FormatAge(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return FormatDuration(time.Since(t))
+}
@@ -0,0 +1,121 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// fieldManager identifies l8zykube as the owner of fields it writes via server-side apply
+const fieldManager = "l8zykube"
+
+// resolveKindGVR maps an apiVersion/kind pair from a manifest to its
+// GroupVersionResource and reports whether the resource is namespaced.
+func (k *KubeClient) resolveKindGVR(ctx context.Context, gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool, error) {
+	if gvk.Kind == "" || gvk.Version == "" {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("apiVersion and kind are required")
+	}
+
+	mapping, err := k.kindMapping(ctx, gvk)
+	if meta.IsNoMatchError(err) {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("unknown kind %s in %s", gvk.Kind, gvk.GroupVersion().String())
+	}
+	if err != nil {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("failed to discover %s: %v", gvk.GroupVersion().String(), err)
+	}
+	return mapping.Resource, mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+// decodeManifest splits a multi-document YAML or JSON manifest into objects.
+// Empty documents are skipped and `kind: List` documents are flattened.
+func decodeManifest(manifest []byte) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096)
+
+	var objects []*unstructured.Unstructured
+	for {
+		var raw map[string]interface{}
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse manifest: %v", err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{Object: raw}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %v", obj.GetKind(), err)
+			}
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+		objects = append(objects, obj)
+	}
+
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("manifest contains no objects")
+	}
+	return objects, nil
+}
+
+// ApplyManifest server-side applies every object in a YAML or JSON manifest,
+// which may contain several documents separated by "---". Namespaced objects
+// without a namespace are created in defaultNamespace. It returns a
+// "resource/name" entry for every object applied before any error occurred.
+func (k *KubeClient) ApplyManifest(ctx context.Context, manifest []byte, defaultNamespace string) ([]string, error) {
+	if err := k.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(defaultNamespace) == "" {
+		defaultNamespace = k.namespace
+	}
+
+	applied := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		name := obj.GetName()
+		if name == "" {
+			return applied, fmt.Errorf("%s is missing metadata.name", obj.GetKind())
+		}
+
+		gvr, namespaced, err := k.resolveKindGVR(ctx, obj.GroupVersionKind())
+		if err != nil {
+			return applied, err
+		}
+
+		opts := metav1.ApplyOptions{FieldManager: fieldManager}
+		if namespaced {
+			if obj.GetNamespace() == "" {
+				obj.SetNamespace(defaultNamespace)
+			}
+			_, err = k.dynamic.Resource(gvr).Namespace(obj.GetNamespace()).Apply(ctx, name, obj, opts)
+		} else {
+			_, err = k.dynamic.Resource(gvr).Apply(ctx, name, obj, opts)
+		}
+		if err != nil {
+			return applied, fmt.Errorf("failed to apply %s/%s: %v", gvr.Resource, name, err)
+		}
+		applied = append(applied, fmt.Sprintf("%s/%s", gvr.Resource, name))
+	}
+
+	return applied, nil
+}
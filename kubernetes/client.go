@@ -2,22 +2,26 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
-	"sigs.k8s.io/yaml"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 // ResourceInfo holds detailed information about a Kubernetes resource
@@ -31,6 +35,21 @@ type ResourceInfo struct {
 	Node      string
 	Namespace string
 	Type      string
+	// Fields holds kind-specific column values keyed by column name (see ColumnsFor)
+	Fields map[string]string
+	// Columns, when set, are the server-provided columns of the row and take
+	// precedence over ColumnsFor
+	Columns []Column
+	// CreatedAt is the creation timestamp; AGE is computed from it when the
+	// table renders so it stays current between refreshes
+	CreatedAt time.Time
+	// Timestamps holds date-typed columns, rendered as ages like AGE
+	Timestamps map[string]time.Time
+	// LastRestartAt is when a container last restarted; RESTARTS shows it as
+	// "3 (5m ago)"
+	LastRestartAt time.Time
+	Labels        map[string]string
+	Annotations   map[string]string
 }
 
 func normalizeNamespaceForList(namespace string) (string, bool) {
@@ -45,23 +64,79 @@ type KubeClient struct {
 	clientset *kubernetes.Clientset
 	config    *rest.Config
 	dynamic   dynamic.Interface
-	disco     discovery.DiscoveryInterface
+	disco     discovery.CachedDiscoveryInterface
+	mapper    *restmapper.DeferredDiscoveryRESTMapper
+
+	contextName string
+	namespace   string
+	options     ClientOptions
+}
+
+// ClientOptions controls where the kubeconfig is loaded from and which
+// context, namespace and access mode the client starts with. Zero values fall
+// back to the kubeconfig defaults, the same way kubectl resolves them.
+type ClientOptions struct {
+	Kubeconfig string
+	Context    string
+	Namespace  string
+	ReadOnly   bool
 }
 
-// NewKubeClient creates a new Kubernetes client
+// ErrReadOnly is returned by mutating calls when the client runs in read-only mode
+var ErrReadOnly = errors.New("client is in read-only mode")
+
+// ContextInfo describes a single context entry from the merged kubeconfig
+type ContextInfo struct {
+	Name      string
+	Cluster   string
+	User      string
+	Namespace string
+	Current   bool
+}
+
+// kubeconfigLoadingRules returns the standard kubeconfig loading rules, which
+// merge every file listed in $KUBECONFIG or fall back to ~/.kube/config.
+// An explicit path (--kubeconfig) takes precedence over both.
+func kubeconfigLoadingRules(explicitPath string) *clientcmd.ClientConfigLoadingRules {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = strings.TrimSpace(explicitPath)
+	return rules
+}
+
+// NewKubeClient creates a new Kubernetes client using the current context
 func NewKubeClient() (*KubeClient, error) {
-	// Try to get kubeconfig from default location
-	var kubeconfig string
-	if home := homedir.HomeDir(); home != "" {
-		kubeconfig = filepath.Join(home, ".kube", "config")
+	return NewKubeClientWithOptions(ClientOptions{})
+}
+
+// NewKubeClientWithOptions creates a new Kubernetes client from the given
+// kubeconfig location and context/namespace overrides.
+func NewKubeClientWithOptions(opts ClientOptions) (*KubeClient, error) {
+	overrides := &clientcmd.ConfigOverrides{
+		CurrentContext: opts.Context,
+		Context:        clientcmdapi.Context{Namespace: opts.Namespace},
+	}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(kubeconfigLoadingRules(opts.Kubeconfig), overrides)
+
+	rawConfig, err := clientConfig.RawConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %v", err)
+	}
+	contextName := opts.Context
+	if contextName == "" {
+		contextName = rawConfig.CurrentContext
 	}
 
 	// Load kubeconfig
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	config, err := clientConfig.ClientConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load kubeconfig: %v", err)
 	}
 
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil || namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+
 	// Create clientset
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -74,23 +149,83 @@ func NewKubeClient() (*KubeClient, error) {
 		return nil, fmt.Errorf("failed to create dynamic client: %v", err)
 	}
 
-	// Discovery client
+	// Discovery client, cached for the lifetime of this client so switching
+	// contexts starts from a clean cache
 	discoClient, err := discovery.NewDiscoveryClientForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create discovery client: %v", err)
 	}
+	cached := memory.NewMemCacheClient(discoClient)
 
 	return &KubeClient{
-		clientset: clientset,
-		config:    config,
-		dynamic:   dyn,
-		disco:     discoClient,
+		clientset:   clientset,
+		config:      config,
+		dynamic:     dyn,
+		disco:       cached,
+		mapper:      restmapper.NewDeferredDiscoveryRESTMapper(cached),
+		contextName: contextName,
+		namespace:   namespace,
+		options:     opts,
 	}, nil
 }
 
+// ListContexts returns every context found in the merged kubeconfig, sorted by name.
+// kubeconfig overrides the default loading rules when non-empty.
+func ListContexts(kubeconfig string) ([]ContextInfo, error) {
+	rawConfig, err := kubeconfigLoadingRules(kubeconfig).Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %v", err)
+	}
+
+	contexts := make([]ContextInfo, 0, len(rawConfig.Contexts))
+	for name, ctx := range rawConfig.Contexts {
+		if ctx == nil {
+			continue
+		}
+		contexts = append(contexts, ContextInfo{
+			Name:      name,
+			Cluster:   ctx.Cluster,
+			User:      ctx.AuthInfo,
+			Namespace: ctx.Namespace,
+			Current:   name == rawConfig.CurrentContext,
+		})
+	}
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].Name < contexts[j].Name })
+
+	return contexts, nil
+}
+
+// Options returns the options this client was created with
+func (k *KubeClient) Options() ClientOptions {
+	return k.options
+}
+
+// ReadOnly reports whether mutating calls are disabled for this client
+func (k *KubeClient) ReadOnly() bool {
+	return k.options.ReadOnly
+}
+
+// ensureWritable guards mutating calls when the client runs in read-only mode
+func (k *KubeClient) ensureWritable() error {
+	if k.options.ReadOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// CurrentContext returns the kubeconfig context this client is bound to
+func (k *KubeClient) CurrentContext() string {
+	return k.contextName
+}
+
+// DefaultNamespace returns the namespace configured for the current context
+func (k *KubeClient) DefaultNamespace() string {
+	return k.namespace
+}
+
 // GetNamespaces returns a list of all namespaces
-func (k *KubeClient) GetNamespaces() ([]string, error) {
-	namespaces, err := k.clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+func (k *KubeClient) GetNamespaces(ctx context.Context) ([]string, error) {
+	namespaces, err := k.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list namespaces: %v", err)
 	}
@@ -103,10 +238,11 @@ func (k *KubeClient) GetNamespaces() ([]string, error) {
 	return nsList, nil
 }
 
-// GetAPIResources returns a list of available API resources
-func (k *KubeClient) GetAPIResources() ([]string, error) {
-	// Get server resources
-	resourceList, err := k.clientset.Discovery().ServerPreferredResources()
+// GetAPIResources returns a list of available API resources. Cached
+// discovery is dropped first, so reloading the list picks up new CRDs.
+func (k *KubeClient) GetAPIResources(ctx context.Context) ([]string, error) {
+	k.mapper.Reset()
+	resourceList, err := discover(ctx, k.disco.ServerPreferredResources)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get server resources: %v", err)
 	}
@@ -125,10 +261,46 @@ func (k *KubeClient) GetAPIResources() ([]string, error) {
 	return resources, nil
 }
 
+// discover runs a discovery call, which cannot be cancelled, and gives up
+// with ctx.Err() once ctx is done. An abandoned call still finishes in the
+// background, bounded by the discovery client's timeout, and fills the cache.
+func discover[T any](ctx context.Context, call func() (T, error)) (T, error) {
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := call()
+		done <- result{value, err}
+	}()
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// kindMapping maps a kind to its resource. Discovery is refreshed once when
+// the kind is unknown, since it may come from a CRD installed since.
+func (k *KubeClient) kindMapping(ctx context.Context, gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping := func() (*meta.RESTMapping, error) {
+		return k.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	m, err := discover(ctx, mapping)
+	if meta.IsNoMatchError(err) {
+		k.mapper.Reset()
+		m, err = discover(ctx, mapping)
+	}
+	return m, err
+}
+
 // resolveResourceGVR attempts to resolve a resource string (e.g., "pods",
 // "deployments", "ingresses.networking.k8s.io") to a GroupVersionResource.
 // It returns the GVR and whether the resource is namespaced.
-func (k *KubeClient) resolveResourceGVR(resource string) (schema.GroupVersionResource, bool, error) {
+func (k *KubeClient) resolveResourceGVR(ctx context.Context, resource string) (schema.GroupVersionResource, bool, error) {
 	resource = strings.ToLower(strings.TrimSpace(resource))
 	if resource == "" {
 		return schema.GroupVersionResource{}, false, fmt.Errorf("resource cannot be empty")
@@ -142,12 +314,26 @@ func (k *KubeClient) resolveResourceGVR(resource string) (schema.GroupVersionRes
 		wantGroup = strings.Join(parts[1:], ".")
 	}
 
-	// Query preferred resources from discovery
-	lists, err := k.disco.ServerPreferredResources()
-	if err != nil {
-		return schema.GroupVersionResource{}, false, fmt.Errorf("failed to discover resources: %v", err)
+	// Query preferred resources from discovery, refreshing the cache once
+	// for resources it does not know yet
+	for attempt := 0; attempt < 2; attempt++ {
+		if attempt > 0 {
+			k.mapper.Reset()
+		}
+		lists, err := discover(ctx, k.disco.ServerPreferredResources)
+		if err != nil {
+			return schema.GroupVersionResource{}, false, fmt.Errorf("failed to discover resources: %v", err)
+		}
+		if gvr, namespaced, ok := findResource(lists, wantResource, wantGroup); ok {
+			return gvr, namespaced, nil
+		}
 	}
+	return schema.GroupVersionResource{}, false, fmt.Errorf("unknown resource: %s", resource)
+}
 
+// findResource looks a resource up by name, and group when not empty, in
+// discovered resource lists
+func findResource(lists []*metav1.APIResourceList, wantResource, wantGroup string) (schema.GroupVersionResource, bool, bool) {
 	for _, rl := range lists {
 		gv, err := schema.ParseGroupVersion(rl.GroupVersion)
 		if err != nil {
@@ -163,20 +349,31 @@ func (k *KubeClient) resolveResourceGVR(resource string) (schema.GroupVersionRes
 				continue
 			}
 			if strings.EqualFold(ar.Name, wantResource) {
-				return schema.GroupVersionResource{Group: gv.Group, Version: gv.Version, Resource: ar.Name}, ar.Namespaced, nil
+				return schema.GroupVersionResource{Group: gv.Group, Version: gv.Version, Resource: ar.Name}, ar.Namespaced, true
 			}
 			// Also allow matching by Kind (singular), converting to plural may be tricky;
 			// we support exact name match primarily.
 		}
 	}
-	return schema.GroupVersionResource{}, false, fmt.Errorf("unknown resource: %s", resource)
+	return schema.GroupVersionResource{}, false, false
+}
+
+// listGenericResources lists any resource using the server-side Table
+// rendering, so every type (including CRDs) gets its real columns. Servers
+// that cannot render tables fall back to a plain dynamic list.
+func (k *KubeClient) listGenericResources(ctx context.Context, resource, namespace string, sel Selector) ([]ResourceInfo, error) {
+	results, _, err := k.listTableResources(ctx, resource, namespace, sel)
+	if err == nil || !isTableUnsupported(err) {
+		return results, err
+	}
+	return k.listUnstructuredResources(ctx, resource, namespace, sel)
 }
 
-// listGenericResources lists any resource via the dynamic client and converts it
-// to a minimal []ResourceInfo for UI consumption.
-func (k *KubeClient) listGenericResources(resource, namespace string) ([]ResourceInfo, error) {
+// listUnstructuredResources lists any resource via the dynamic client and
+// converts it to a minimal []ResourceInfo for UI consumption.
+func (k *KubeClient) listUnstructuredResources(ctx context.Context, resource, namespace string, sel Selector) ([]ResourceInfo, error) {
 	ns, isAll := normalizeNamespaceForList(namespace)
-	gvr, namespaced, err := k.resolveResourceGVR(resource)
+	gvr, namespaced, err := k.resolveResourceGVR(ctx, resource)
 	if err != nil {
 		return nil, err
 	}
@@ -184,221 +381,240 @@ func (k *KubeClient) listGenericResources(resource, namespace string) ([]Resourc
 	var ulist *unstructured.UnstructuredList
 	if namespaced {
 		if isAll {
-			ulist, err = k.dynamic.Resource(gvr).List(context.TODO(), metav1.ListOptions{})
+			ulist, err = k.dynamic.Resource(gvr).List(ctx, sel.ListOptions())
 		} else {
-			ulist, err = k.dynamic.Resource(gvr).Namespace(ns).List(context.TODO(), metav1.ListOptions{})
+			ulist, err = k.dynamic.Resource(gvr).Namespace(ns).List(ctx, sel.ListOptions())
 		}
 	} else {
-		ulist, err = k.dynamic.Resource(gvr).List(context.TODO(), metav1.ListOptions{})
+		ulist, err = k.dynamic.Resource(gvr).List(ctx, sel.ListOptions())
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to list %s: %v", resource, err)
 	}
 
 	results := make([]ResourceInfo, 0, len(ulist.Items))
-	for _, item := range ulist.Items {
-		// Age
-		age := "Unknown"
-		if !item.GetCreationTimestamp().Time.IsZero() {
-			hours := int(time.Since(item.GetCreationTimestamp().Time).Hours())
-			age = fmt.Sprintf("%dh", hours)
-		}
-		ri := ResourceInfo{
-			Name:      item.GetName(),
-			Ready:     "<none>",
-			Status:    "<none>",
-			Restarts:  "<none>",
-			Age:       age,
-			IP:        "<none>",
-			Node:      "<none>",
-			Namespace: item.GetNamespace(),
-			Type:      resource,
-		}
-		results = append(results, ri)
+	for i := range ulist.Items {
+		results = append(results, genericResourceInfo(&ulist.Items[i], resource))
 	}
 
 	return results, nil
 }
 
+// genericResourceInfo converts any object to a ResourceInfo carrying only its
+// name, namespace and age.
+func genericResourceInfo(item *unstructured.Unstructured, resource string) ResourceInfo {
+	created := item.GetCreationTimestamp().Time
+	info := ResourceInfo{
+		Name:        item.GetName(),
+		Ready:       "<none>",
+		Status:      "<none>",
+		Restarts:    "<none>",
+		Age:         FormatAge(created),
+		CreatedAt:   created,
+		IP:          "<none>",
+		Node:        "<none>",
+		Namespace:   item.GetNamespace(),
+		Type:        resource,
+		Labels:      item.GetLabels(),
+		Annotations: item.GetAnnotations(),
+	}
+	if extract, ok := genericFieldExtractors[resource]; ok {
+		info.Fields = extract(item)
+	}
+	return info
+}
+
 // GetPodsDetailed returns detailed pod information
-func (k *KubeClient) GetPodsDetailed(namespace string) ([]ResourceInfo, error) {
+func (k *KubeClient) GetPodsDetailed(ctx context.Context, namespace string, sel Selector) ([]ResourceInfo, error) {
 	ns, _ := normalizeNamespaceForList(namespace)
-	pods, err := k.clientset.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{})
+	pods, err := k.clientset.CoreV1().Pods(ns).List(ctx, sel.ListOptions())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods in namespace %s: %v", namespace, err)
 	}
 
 	var podList []ResourceInfo
-	for _, pod := range pods.Items {
-		// Calculate ready status
-		ready := "0/0"
-		if len(pod.Spec.Containers) > 0 {
-			readyContainers := 0
-			for _, containerStatus := range pod.Status.ContainerStatuses {
-				if containerStatus.Ready {
-					readyContainers++
-				}
-			}
-			ready = fmt.Sprintf("%d/%d", readyContainers, len(pod.Spec.Containers))
-		}
-
-		// Get pod status
-		status := string(pod.Status.Phase)
-		if pod.Status.Phase == "" {
-			status = "Unknown"
-		}
-
-		// Calculate restarts
-		restarts := int32(0)
-		for _, containerStatus := range pod.Status.ContainerStatuses {
-			restarts += containerStatus.RestartCount
-		}
+	for i := range pods.Items {
+		podList = append(podList, podResourceInfo(&pods.Items[i]))
+	}
 
-		// Calculate age in hours
-		age := "Unknown"
-		if !pod.CreationTimestamp.IsZero() {
-			hours := int(time.Since(pod.CreationTimestamp.Time).Hours())
-			age = fmt.Sprintf("%dh", hours)
-		}
+	return podList, nil
+}
 
-		// Get IP
-		ip := pod.Status.PodIP
-		if ip == "" {
-			ip = "<none>"
-		}
+func podResourceInfo(pod *corev1.Pod) ResourceInfo {
+	summary := summarizePod(pod)
 
-		// Get node
-		node := pod.Spec.NodeName
-		if node == "" {
-			node = "<none>"
-		}
+	// Get IP
+	ip := pod.Status.PodIP
+	if ip == "" {
+		ip = "<none>"
+	}
 
-		podList = append(podList, ResourceInfo{
-			Name:      pod.Name,
-			Ready:     ready,
-			Status:    status,
-			Restarts:  fmt.Sprintf("%d", restarts),
-			Age:       age,
-			IP:        ip,
-			Node:      node,
-			Namespace: pod.Namespace,
-			Type:      "Pod",
-		})
+	// Get node
+	node := pod.Spec.NodeName
+	if node == "" {
+		node = "<none>"
 	}
 
-	return podList, nil
+	return ResourceInfo{
+		Name:          pod.Name,
+		Ready:         summary.ready,
+		Status:        summary.status,
+		Restarts:      fmt.Sprint(summary.restarts),
+		Age:           FormatAge(pod.CreationTimestamp.Time),
+		CreatedAt:     pod.CreationTimestamp.Time,
+		LastRestartAt: summary.lastRestart,
+		IP:            ip,
+		Node:          node,
+		Namespace:     pod.Namespace,
+		Labels:        pod.Labels,
+		Annotations:   pod.Annotations,
+		Type:          "Pod",
+	}
 }
 
 // GetServicesDetailed returns detailed service information
-func (k *KubeClient) GetServicesDetailed(namespace string) ([]ResourceInfo, error) {
+func (k *KubeClient) GetServicesDetailed(ctx context.Context, namespace string, sel Selector) ([]ResourceInfo, error) {
 	ns, _ := normalizeNamespaceForList(namespace)
-	services, err := k.clientset.CoreV1().Services(ns).List(context.TODO(), metav1.ListOptions{})
+	services, err := k.clientset.CoreV1().Services(ns).List(ctx, sel.ListOptions())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list services in namespace %s: %v", namespace, err)
 	}
 
 	var serviceList []ResourceInfo
-	for _, service := range services.Items {
-		// Get service type
-		serviceType := string(service.Spec.Type)
-		if serviceType == "" {
-			serviceType = "ClusterIP"
-		}
+	for i := range services.Items {
+		serviceList = append(serviceList, serviceResourceInfo(&services.Items[i]))
+	}
 
-		// Get cluster IP
-		clusterIP := service.Spec.ClusterIP
-		if clusterIP == "" {
-			clusterIP = "<none>"
-		}
+	return serviceList, nil
+}
 
-		// Get external IP
-		externalIP := "<none>"
-		if len(service.Spec.ExternalIPs) > 0 {
-			externalIP = service.Spec.ExternalIPs[0]
-		} else if service.Spec.Type == "LoadBalancer" && len(service.Status.LoadBalancer.Ingress) > 0 {
-			externalIP = service.Status.LoadBalancer.Ingress[0].IP
-		}
+func serviceResourceInfo(service *corev1.Service) ResourceInfo {
+	// Get service type
+	serviceType := string(service.Spec.Type)
+	if serviceType == "" {
+		serviceType = "ClusterIP"
+	}
+
+	// Get cluster IP
+	clusterIP := service.Spec.ClusterIP
+	if clusterIP == "" {
+		clusterIP = "<none>"
+	}
+
+	return ResourceInfo{
+		Name:        service.Name,
+		Status:      "Active",
+		Age:         FormatAge(service.CreationTimestamp.Time),
+		CreatedAt:   service.CreationTimestamp.Time,
+		Namespace:   service.Namespace,
+		Type:        "Service",
+		Labels:      service.Labels,
+		Annotations: service.Annotations,
+		Fields: map[string]string{
+			"TYPE":        serviceType,
+			"CLUSTER-IP":  clusterIP,
+			"EXTERNAL-IP": serviceExternalIPs(service),
+			"PORTS":       servicePorts(service),
+		},
+	}
+}
 
-		// Get ports
-		ports := "<none>"
-		if len(service.Spec.Ports) > 0 {
-			port := service.Spec.Ports[0]
-			ports = fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+// serviceExternalIPs renders the EXTERNAL-IP column the way kubectl does
+func serviceExternalIPs(service *corev1.Service) string {
+	var ips []string
+	switch service.Spec.Type {
+	case corev1.ServiceTypeExternalName:
+		return service.Spec.ExternalName
+	case corev1.ServiceTypeLoadBalancer:
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				ips = append(ips, ingress.IP)
+			} else if ingress.Hostname != "" {
+				ips = append(ips, ingress.Hostname)
+			}
 		}
-
-		// Calculate age in hours
-		age := "Unknown"
-		if !service.CreationTimestamp.IsZero() {
-			hours := int(time.Since(service.CreationTimestamp.Time).Hours())
-			age = fmt.Sprintf("%dh", hours)
+		ips = append(ips, service.Spec.ExternalIPs...)
+		if len(ips) == 0 {
+			return "<pending>"
 		}
-
-		serviceList = append(serviceList, ResourceInfo{
-			Name:      service.Name,
-			Ready:     serviceType,
-			Status:    "Active",
-			Restarts:  ports,
-			Age:       age,
-			IP:        clusterIP,
-			Node:      externalIP,
-			Namespace: service.Namespace,
-			Type:      "Service",
-		})
+	default:
+		ips = append(ips, service.Spec.ExternalIPs...)
 	}
+	if len(ips) == 0 {
+		return "<none>"
+	}
+	return strings.Join(ips, ",")
+}
 
-	return serviceList, nil
+// servicePorts renders ports as "80/TCP" or "80:30080/TCP" for node ports
+func servicePorts(service *corev1.Service) string {
+	if len(service.Spec.Ports) == 0 {
+		return "<none>"
+	}
+	ports := make([]string, 0, len(service.Spec.Ports))
+	for _, port := range service.Spec.Ports {
+		if port.NodePort > 0 {
+			ports = append(ports, fmt.Sprintf("%d:%d/%s", port.Port, port.NodePort, port.Protocol))
+		} else {
+			ports = append(ports, fmt.Sprintf("%d/%s", port.Port, port.Protocol))
+		}
+	}
+	return strings.Join(ports, ",")
 }
 
 // GetDeploymentsDetailed returns detailed deployment information
-func (k *KubeClient) GetDeploymentsDetailed(namespace string) ([]ResourceInfo, error) {
+func (k *KubeClient) GetDeploymentsDetailed(ctx context.Context, namespace string, sel Selector) ([]ResourceInfo, error) {
 	ns, _ := normalizeNamespaceForList(namespace)
-	deployments, err := k.clientset.AppsV1().Deployments(ns).List(context.TODO(), metav1.ListOptions{})
+	deployments, err := k.clientset.AppsV1().Deployments(ns).List(ctx, sel.ListOptions())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list deployments in namespace %s: %v", namespace, err)
 	}
 
 	var deploymentList []ResourceInfo
-	for _, deployment := range deployments.Items {
-		// Get ready replicas
-		ready := "0/0"
-		if deployment.Spec.Replicas != nil {
-			ready = fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, *deployment.Spec.Replicas)
-		}
-
-		// Get status
-		status := "Unknown"
-		if deployment.Status.ReadyReplicas == *deployment.Spec.Replicas {
-			status = "Available"
-		} else if deployment.Status.ReadyReplicas > 0 {
-			status = "Progressing"
-		}
-
-		// Calculate age in hours
-		age := "Unknown"
-		if !deployment.CreationTimestamp.IsZero() {
-			hours := int(time.Since(deployment.CreationTimestamp.Time).Hours())
-			age = fmt.Sprintf("%dh", hours)
-		}
-
-		deploymentList = append(deploymentList, ResourceInfo{
-			Name:      deployment.Name,
-			Ready:     ready,
-			Status:    status,
-			Restarts:  fmt.Sprintf("%d", deployment.Status.UpdatedReplicas),
-			Age:       age,
-			IP:        "<none>",
-			Node:      "<none>",
-			Namespace: deployment.Namespace,
-			Type:      "Deployment",
-		})
+	for i := range deployments.Items {
+		deploymentList = append(deploymentList, deploymentResourceInfo(&deployments.Items[i]))
 	}
 
 	return deploymentList, nil
 }
 
+func deploymentResourceInfo(deployment *appsv1.Deployment) ResourceInfo {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	// Get ready replicas
+	ready := fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, desired)
+
+	// Get status
+	status := "Unknown"
+	if deployment.Status.ReadyReplicas == desired {
+		status = "Available"
+	} else if deployment.Status.ReadyReplicas > 0 {
+		status = "Progressing"
+	}
+
+	return ResourceInfo{
+		Name:        deployment.Name,
+		Ready:       ready,
+		Status:      status,
+		Age:         FormatAge(deployment.CreationTimestamp.Time),
+		CreatedAt:   deployment.CreationTimestamp.Time,
+		Namespace:   deployment.Namespace,
+		Type:        "Deployment",
+		Labels:      deployment.Labels,
+		Annotations: deployment.Annotations,
+		Fields: map[string]string{
+			"UP-TO-DATE": fmt.Sprintf("%d", deployment.Status.UpdatedReplicas),
+			"AVAILABLE":  fmt.Sprintf("%d", deployment.Status.AvailableReplicas),
+		},
+	}
+}
+
 // GetConfigMaps returns configmaps in a specific namespace
-func (k *KubeClient) GetConfigMaps(namespace string) ([]string, error) {
-	configmaps, err := k.clientset.CoreV1().ConfigMaps(namespace).List(context.TODO(), metav1.ListOptions{})
+func (k *KubeClient) GetConfigMaps(ctx context.Context, namespace string) ([]string, error) {
+	configmaps, err := k.clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list configmaps in namespace %s: %v", namespace, err)
 	}
@@ -412,8 +628,8 @@ func (k *KubeClient) GetConfigMaps(namespace string) ([]string, error) {
 }
 
 // GetSecrets returns secrets in a specific namespace
-func (k *KubeClient) GetSecrets(namespace string) ([]string, error) {
-	secrets, err := k.clientset.CoreV1().Secrets(namespace).List(context.TODO(), metav1.ListOptions{})
+func (k *KubeClient) GetSecrets(ctx context.Context, namespace string) ([]string, error) {
+	secrets, err := k.clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list secrets in namespace %s: %v", namespace, err)
 	}
@@ -427,103 +643,87 @@ func (k *KubeClient) GetSecrets(namespace string) ([]string, error) {
 }
 
 // GetResourceList returns a list of resources for a specific type and namespace
-func (k *KubeClient) GetResourceList(resourceType, namespace string) ([]ResourceInfo, error) {
-	switch resourceType {
-	case "pods":
-		return k.GetPodsDetailed(namespace)
-	case "services":
-		return k.GetServicesDetailed(namespace)
-	case "deployments":
-		return k.GetDeploymentsDetailed(namespace)
-	default:
-		// Fallback to dynamic client for any other resource
-		return k.listGenericResources(resourceType, namespace)
-	}
+func (k *KubeClient) GetResourceList(ctx context.Context, resourceType, namespace string) ([]ResourceInfo, error) {
+	return k.GetResourceListDetailed(ctx, resourceType, namespace, Selector{})
 }
 
-// GetResourceListDetailed returns detailed resource information for a specific type
-func (k *KubeClient) GetResourceListDetailed(resourceType, namespace string) ([]ResourceInfo, error) {
+// GetResourceListDetailed returns detailed resource information for a specific
+// type, limited to the objects matching sel
+func (k *KubeClient) GetResourceListDetailed(ctx context.Context, resourceType, namespace string, sel Selector) ([]ResourceInfo, error) {
 	switch resourceType {
 	case "pods":
-		return k.GetPodsDetailed(namespace)
+		return k.GetPodsDetailed(ctx, namespace, sel)
 	case "services":
-		return k.GetServicesDetailed(namespace)
+		return k.GetServicesDetailed(ctx, namespace, sel)
 	case "deployments":
-		return k.GetDeploymentsDetailed(namespace)
+		return k.GetDeploymentsDetailed(ctx, namespace, sel)
 	default:
 		// Use dynamic client detailed listing for any other resource
-		return k.listGenericResources(resourceType, namespace)
+		return k.listGenericResources(ctx, resourceType, namespace, sel)
 	}
 }
 
 // TestConnection tests the connection to the Kubernetes cluster
-func (k *KubeClient) TestConnection() error {
-	_, err := k.clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{Limit: 1})
+func (k *KubeClient) TestConnection(ctx context.Context) error {
+	_, err := k.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{Limit: 1})
 	if err != nil {
 		return fmt.Errorf("failed to connect to Kubernetes cluster: %v", err)
 	}
 	return nil
 }
 
-// GetPodLogs retrieves logs from a specific pod
-func (k *KubeClient) GetPodLogs(namespace, podName string, tailLines int64) (string, error) {
-	podLogOpts := &corev1.PodLogOptions{
-		TailLines: &tailLines,
-	}
-
-	req := k.clientset.CoreV1().Pods(namespace).GetLogs(podName, podLogOpts)
-	podLogs, err := req.Stream(context.TODO())
-	if err != nil {
-		return "", fmt.Errorf("failed to get logs for pod %s in namespace %s: %v", podName, namespace, err)
-	}
-	defer podLogs.Close()
-
-	buf := make([]byte, 0, 1024*1024) // 1MB buffer
-	tmp := make([]byte, 1024)
-	for {
-		n, err := podLogs.Read(tmp)
-		if n > 0 {
-			buf = append(buf, tmp[:n]...)
-		}
-		if err != nil {
-			break
-		}
-	}
-
-	return string(buf), nil
+// DeleteOptions controls how DeleteResource removes an object
+type DeleteOptions struct {
+	// GracePeriodSeconds overrides the object's termination grace period when set.
+	// Values below 1 are raised to 1 unless Force is set.
+	GracePeriodSeconds *int64
+	// Force removes the object immediately, like `kubectl delete --force --grace-period=0`
+	Force bool
+	// PropagationPolicy selects foreground, background or orphan deletion of
+	// dependents. Empty uses the server default.
+	PropagationPolicy metav1.DeletionPropagation
 }
 
-// DescribeResource fetches a resource by type/name/namespace and returns a YAML representation
-// similar to `kubectl get <resource> <name> -n <ns> -o yaml`.
-// This provides a compact, readable description suitable for display in a modal.
-func (k *KubeClient) DescribeResource(resourceType, namespace, name string) (string, error) {
+// DeleteResource deletes a resource by type/name/namespace through the dynamic client
+func (k *KubeClient) DeleteResource(ctx context.Context, resourceType, namespace, name string, opts DeleteOptions) error {
+	if err := k.ensureWritable(); err != nil {
+		return err
+	}
 	if strings.TrimSpace(resourceType) == "" || strings.TrimSpace(name) == "" {
-		return "", fmt.Errorf("resourceType and name are required")
+		return fmt.Errorf("resourceType and name are required")
 	}
 
-	gvr, namespaced, err := k.resolveResourceGVR(resourceType)
+	gvr, namespaced, err := k.resolveResourceGVR(ctx, resourceType)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	deleteOpts := metav1.DeleteOptions{GracePeriodSeconds: opts.GracePeriodSeconds}
+	if opts.Force {
+		zero := int64(0)
+		deleteOpts.GracePeriodSeconds = &zero
+	} else if g := opts.GracePeriodSeconds; g != nil && *g < 1 {
+		// The server treats a grace period of 0 as a force delete; like
+		// kubectl, only Force may ask for one
+		one := int64(1)
+		deleteOpts.GracePeriodSeconds = &one
+	}
+	if opts.PropagationPolicy != "" {
+		policy := opts.PropagationPolicy
+		deleteOpts.PropagationPolicy = &policy
 	}
 
-	var obj *unstructured.Unstructured
 	if namespaced {
 		if strings.TrimSpace(namespace) == "" {
 			namespace = "default"
 		}
-		obj, err = k.dynamic.Resource(gvr).Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		err = k.dynamic.Resource(gvr).Namespace(namespace).Delete(ctx, name, deleteOpts)
 	} else {
-		obj, err = k.dynamic.Resource(gvr).Get(context.TODO(), name, metav1.GetOptions{})
+		err = k.dynamic.Resource(gvr).Delete(ctx, name, deleteOpts)
 	}
 	if err != nil {
-		return "", fmt.Errorf("failed to get %s/%s: %v", resourceType, name, err)
+		return fmt.Errorf("failed to delete %s/%s: %v", resourceType, name, err)
 	}
 
-	// Marshal the unstructured object to YAML for a readable description
-	y, err := yaml.Marshal(obj.Object)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal resource to YAML: %v")
-	}
-
-	return string(y), nil
+	return nil
 }
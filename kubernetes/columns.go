@@ -0,0 +1,307 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Column describes a table column shown for a resource kind, after NAME and
+// (when listing several namespaces) NAMESPACE.
+type Column struct {
+	Name     string
+	MinWidth int
+	MaxWidth int
+}
+
+var ageColumn = Column{Name: "AGE", MinWidth: 6, MaxWidth: 16}
+
+// LabelsColumn is the optional column listing every label, like
+// `kubectl get --show-labels`
+var LabelsColumn = Column{Name: "LABELS", MinWidth: 6, MaxWidth: 60}
+
+// kindColumns mirrors the columns of `kubectl get` for the kinds we convert
+// ourselves, keyed by the plural resource name.
+var kindColumns = map[string][]Column{
+	"pods": {
+		{Name: "READY", MinWidth: 5, MaxWidth: 12},
+		{Name: "STATUS", MinWidth: 8, MaxWidth: 24},
+		{Name: "RESTARTS", MinWidth: 8, MaxWidth: 20},
+		ageColumn,
+		{Name: "IP", MinWidth: 8, MaxWidth: 24},
+		{Name: "NODE", MinWidth: 8, MaxWidth: 32},
+	},
+	"services": {
+		{Name: "TYPE", MinWidth: 9, MaxWidth: 14},
+		{Name: "CLUSTER-IP", MinWidth: 10, MaxWidth: 40},
+		{Name: "EXTERNAL-IP", MinWidth: 11, MaxWidth: 40},
+		{Name: "PORTS", MinWidth: 8, MaxWidth: 40},
+		ageColumn,
+	},
+	"deployments": {
+		{Name: "READY", MinWidth: 5, MaxWidth: 12},
+		{Name: "UP-TO-DATE", MinWidth: 10, MaxWidth: 10},
+		{Name: "AVAILABLE", MinWidth: 9, MaxWidth: 9},
+		ageColumn,
+	},
+	"statefulsets": {
+		{Name: "READY", MinWidth: 5, MaxWidth: 12},
+		ageColumn,
+	},
+	"daemonsets": {
+		{Name: "DESIRED", MinWidth: 7, MaxWidth: 7},
+		{Name: "CURRENT", MinWidth: 7, MaxWidth: 7},
+		{Name: "READY", MinWidth: 5, MaxWidth: 7},
+		{Name: "UP-TO-DATE", MinWidth: 10, MaxWidth: 10},
+		{Name: "AVAILABLE", MinWidth: 9, MaxWidth: 9},
+		ageColumn,
+	},
+	"replicasets": {
+		{Name: "DESIRED", MinWidth: 7, MaxWidth: 7},
+		{Name: "CURRENT", MinWidth: 7, MaxWidth: 7},
+		{Name: "READY", MinWidth: 5, MaxWidth: 7},
+		ageColumn,
+	},
+	"jobs": {
+		{Name: "COMPLETIONS", MinWidth: 11, MaxWidth: 11},
+		ageColumn,
+	},
+	"configmaps": {
+		{Name: "DATA", MinWidth: 4, MaxWidth: 6},
+		ageColumn,
+	},
+	"secrets": {
+		{Name: "TYPE", MinWidth: 6, MaxWidth: 40},
+		{Name: "DATA", MinWidth: 4, MaxWidth: 6},
+		ageColumn,
+	},
+	"namespaces": {
+		{Name: "STATUS", MinWidth: 8, MaxWidth: 12},
+		ageColumn,
+	},
+	"nodes": {
+		{Name: "STATUS", MinWidth: 8, MaxWidth: 32},
+		{Name: "ROLES", MinWidth: 6, MaxWidth: 24},
+		ageColumn,
+		{Name: "VERSION", MinWidth: 8, MaxWidth: 20},
+	},
+	"persistentvolumeclaims": {
+		{Name: "STATUS", MinWidth: 7, MaxWidth: 10},
+		{Name: "VOLUME", MinWidth: 8, MaxWidth: 48},
+		{Name: "CAPACITY", MinWidth: 8, MaxWidth: 10},
+		{Name: "ACCESS MODES", MinWidth: 12, MaxWidth: 16},
+		ageColumn,
+	},
+}
+
+// resourceTypeAliases maps the singular types used by the typed list
+// functions (ResourceInfo.Type) to their plural resource names.
+var resourceTypeAliases = map[string]string{
+	"pod":        "pods",
+	"service":    "services",
+	"deployment": "deployments",
+}
+
+// ColumnsFor returns the columns shown for a resource type, which may be a
+// plural resource name ("services") or a ResourceInfo.Type ("Service").
+// Unknown types only get an AGE column.
+func ColumnsFor(resourceType string) []Column {
+	key := strings.ToLower(strings.TrimSpace(resourceType))
+	if alias, ok := resourceTypeAliases[key]; ok {
+		key = alias
+	}
+	if columns, ok := kindColumns[key]; ok {
+		return columns
+	}
+	return []Column{ageColumn}
+}
+
+// Value returns the cell shown for a column. Ages are computed from the stored
+// timestamps, per-kind values live in Fields, and the common columns fall back
+// to the dedicated struct fields.
+func (r ResourceInfo) Value(column string) string {
+	if column == "AGE" && !r.CreatedAt.IsZero() {
+		return FormatAge(r.CreatedAt)
+	}
+	if column == "RESTARTS" && !r.LastRestartAt.IsZero() {
+		return fmt.Sprintf("%s (%s ago)", r.Restarts, FormatAge(r.LastRestartAt))
+	}
+	if t, ok := r.Timestamps[column]; ok {
+		return FormatAge(t)
+	}
+	if column == LabelsColumn.Name {
+		return FormatLabels(r.Labels)
+	}
+	if v, ok := r.Fields[column]; ok {
+		return v
+	}
+	switch column {
+	case "NAME":
+		return r.Name
+	case "NAMESPACE":
+		return r.Namespace
+	case "READY":
+		return r.Ready
+	case "STATUS":
+		return r.Status
+	case "RESTARTS":
+		return r.Restarts
+	case "AGE":
+		return r.Age
+	case "IP":
+		return r.IP
+	case "NODE":
+		return r.Node
+	}
+	return ""
+}
+
+// genericFieldExtractors fill the per-kind columns of resources that are
+// listed through the dynamic client.
+var genericFieldExtractors = map[string]func(obj *unstructured.Unstructured) map[string]string{
+	"statefulsets": func(obj *unstructured.Unstructured) map[string]string {
+		return map[string]string{
+			"READY": fmt.Sprintf("%d/%d", nestedInt(obj, "status", "readyReplicas"), nestedIntDefault(obj, 1, "spec", "replicas")),
+		}
+	},
+	"daemonsets": func(obj *unstructured.Unstructured) map[string]string {
+		return map[string]string{
+			"DESIRED":    fmt.Sprint(nestedInt(obj, "status", "desiredNumberScheduled")),
+			"CURRENT":    fmt.Sprint(nestedInt(obj, "status", "currentNumberScheduled")),
+			"READY":      fmt.Sprint(nestedInt(obj, "status", "numberReady")),
+			"UP-TO-DATE": fmt.Sprint(nestedInt(obj, "status", "updatedNumberScheduled")),
+			"AVAILABLE":  fmt.Sprint(nestedInt(obj, "status", "numberAvailable")),
+		}
+	},
+	"replicasets": func(obj *unstructured.Unstructured) map[string]string {
+		return map[string]string{
+			"DESIRED": fmt.Sprint(nestedIntDefault(obj, 1, "spec", "replicas")),
+			"CURRENT": fmt.Sprint(nestedInt(obj, "status", "replicas")),
+			"READY":   fmt.Sprint(nestedInt(obj, "status", "readyReplicas")),
+		}
+	},
+	"jobs": func(obj *unstructured.Unstructured) map[string]string {
+		return map[string]string{
+			"COMPLETIONS": fmt.Sprintf("%d/%d", nestedInt(obj, "status", "succeeded"), nestedIntDefault(obj, 1, "spec", "completions")),
+		}
+	},
+	"configmaps": func(obj *unstructured.Unstructured) map[string]string {
+		data, _, _ := unstructured.NestedMap(obj.Object, "data")
+		binary, _, _ := unstructured.NestedMap(obj.Object, "binaryData")
+		return map[string]string{"DATA": fmt.Sprint(len(data) + len(binary))}
+	},
+	"secrets": func(obj *unstructured.Unstructured) map[string]string {
+		secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+		data, _, _ := unstructured.NestedMap(obj.Object, "data")
+		return map[string]string{"TYPE": secretType, "DATA": fmt.Sprint(len(data))}
+	},
+	"namespaces": func(obj *unstructured.Unstructured) map[string]string {
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		return map[string]string{"STATUS": phase}
+	},
+	"nodes": func(obj *unstructured.Unstructured) map[string]string {
+		version, _, _ := unstructured.NestedString(obj.Object, "status", "nodeInfo", "kubeletVersion")
+		return map[string]string{
+			"STATUS":  nodeStatus(obj),
+			"ROLES":   nodeRoles(obj),
+			"VERSION": version,
+		}
+	},
+	"persistentvolumeclaims": func(obj *unstructured.Unstructured) map[string]string {
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		volume, _, _ := unstructured.NestedString(obj.Object, "spec", "volumeName")
+		capacity, _, _ := unstructured.NestedString(obj.Object, "status", "capacity", "storage")
+		modes, _, _ := unstructured.NestedStringSlice(obj.Object, "status", "accessModes")
+		return map[string]string{
+			"STATUS":       phase,
+			"VOLUME":       volume,
+			"CAPACITY":     capacity,
+			"ACCESS MODES": accessModesShort(modes),
+		}
+	},
+}
+
+// FormatLabels renders labels as sorted "key=value" pairs separated by commas,
+// or "<none>"
+func FormatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "<none>"
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+	return strings.Join(pairs, ",")
+}
+
+func nestedInt(obj *unstructured.Unstructured, fields ...string) int64 {
+	return nestedIntDefault(obj, 0, fields...)
+}
+
+func nestedIntDefault(obj *unstructured.Unstructured, def int64, fields ...string) int64 {
+	v, found, err := unstructured.NestedInt64(obj.Object, fields...)
+	if !found || err != nil {
+		return def
+	}
+	return v
+}
+
+func nodeStatus(obj *unstructured.Unstructured) string {
+	status := "Unknown"
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != "Ready" {
+			continue
+		}
+		if cond["status"] == "True" {
+			status = "Ready"
+		} else {
+			status = "NotReady"
+		}
+	}
+	if unschedulable, _, _ := unstructured.NestedBool(obj.Object, "spec", "unschedulable"); unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
+
+func nodeRoles(obj *unstructured.Unstructured) string {
+	const rolePrefix = "node-role.kubernetes.io/"
+	var roles []string
+	for label := range obj.GetLabels() {
+		if strings.HasPrefix(label, rolePrefix) && len(label) > len(rolePrefix) {
+			roles = append(roles, strings.TrimPrefix(label, rolePrefix))
+		}
+	}
+	if len(roles) == 0 {
+		return "<none>"
+	}
+	sort.Strings(roles)
+	return strings.Join(roles, ",")
+}
+
+func accessModesShort(modes []string) string {
+	short := map[string]string{
+		"ReadWriteOnce":    "RWO",
+		"ReadOnlyMany":     "ROX",
+		"ReadWriteMany":    "RWX",
+		"ReadWriteOncePod": "RWOP",
+	}
+	out := make([]string, 0, len(modes))
+	for _, mode := range modes {
+		if s, ok := short[mode]; ok {
+			out = append(out, s)
+		} else {
+			out = append(out, mode)
+		}
+	}
+	return strings.Join(out, ",")
+}
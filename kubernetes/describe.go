@@ -0,0 +1,1155 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+// lastAppliedAnnotation holds the manifest of the last `kubectl apply`; like
+// kubectl, describe leaves it out since it repeats the whole object
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Description is an object rendered for reading: Text is the kubectl-style
+// describe output and YAML the object as the server returns it
+type Description struct {
+	Text string
+	YAML string
+}
+
+// DescribeResource fetches a resource by type/name/namespace and renders it
+// like `kubectl describe`, followed by the events that refer to it. Pods,
+// deployments, services, nodes, persistent volume claims and jobs have
+// their own layout; any other kind lists its fields generically.
+func (k *KubeClient) DescribeResource(ctx context.Context, resourceType, namespace, name string) (*Description, error) {
+	if strings.TrimSpace(resourceType) == "" || strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("resourceType and name are required")
+	}
+
+	gvr, namespaced, err := k.resolveResourceGVR(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *unstructured.Unstructured
+	if namespaced {
+		obj, err = k.dynamic.Resource(gvr).Namespace(namespaceOrDefault(namespace)).Get(ctx, name, metav1.GetOptions{})
+	} else {
+		obj, err = k.dynamic.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s/%s: %v", resourceType, name, err)
+	}
+
+	y, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource to YAML: %v", err)
+	}
+
+	w := newDescribeWriter()
+	k.describeObject(ctx, w, gvr, obj)
+	w.line(0, "")
+	k.describeEvents(ctx, w, obj)
+	return &Description{Text: w.String(), YAML: string(y)}, nil
+}
+
+// describeObject renders obj with the layout of its kind, falling back to
+// the generic one if the object does not decode into its typed form
+func (k *KubeClient) describeObject(ctx context.Context, w *describeWriter, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) {
+	content := obj.UnstructuredContent()
+	convert := runtime.DefaultUnstructuredConverter.FromUnstructured
+	switch gvr.GroupResource() {
+	case schema.GroupResource{Resource: "pods"}:
+		var pod corev1.Pod
+		if convert(content, &pod) == nil {
+			describePod(w, &pod)
+			return
+		}
+	case schema.GroupResource{Group: "apps", Resource: "deployments"}:
+		var deployment appsv1.Deployment
+		if convert(content, &deployment) == nil {
+			k.describeDeployment(ctx, w, &deployment)
+			return
+		}
+	case schema.GroupResource{Resource: "services"}:
+		var svc corev1.Service
+		if convert(content, &svc) == nil {
+			k.describeService(ctx, w, &svc)
+			return
+		}
+	case schema.GroupResource{Resource: "nodes"}:
+		var node corev1.Node
+		if convert(content, &node) == nil {
+			k.describeNode(ctx, w, &node)
+			return
+		}
+	case schema.GroupResource{Resource: "persistentvolumeclaims"}:
+		var pvc corev1.PersistentVolumeClaim
+		if convert(content, &pvc) == nil {
+			k.describePersistentVolumeClaim(ctx, w, &pvc)
+			return
+		}
+	case schema.GroupResource{Group: "batch", Resource: "jobs"}:
+		var job batchv1.Job
+		if convert(content, &job) == nil {
+			describeJob(w, &job)
+			return
+		}
+	}
+	describeUnstructured(w, obj)
+}
+
+// describeWriter lays out describe output: every line is indented by its
+// level, and tab separated columns are aligned across neighbouring lines
+type describeWriter struct {
+	buf bytes.Buffer
+	tw  *tabwriter.Writer
+}
+
+func newDescribeWriter() *describeWriter {
+	w := &describeWriter{}
+	w.tw = tabwriter.NewWriter(&w.buf, 0, 8, 2, ' ', 0)
+	return w
+}
+
+func (w *describeWriter) line(level int, format string, args ...interface{}) {
+	fmt.Fprintf(w.tw, strings.Repeat("  ", level)+format+"\n", args...)
+}
+
+// list writes "title: first" followed by the other items aligned below it,
+// or <none>
+func (w *describeWriter) list(level int, title string, items []string) {
+	if len(items) == 0 {
+		w.line(level, "%s:\t<none>", title)
+		return
+	}
+	w.line(level, "%s:\t%s", title, items[0])
+	for _, item := range items[1:] {
+		w.line(level, "\t%s", item)
+	}
+}
+
+// stringMap writes a map such as labels as sorted key=value items
+func (w *describeWriter) stringMap(level int, title string, m map[string]string) {
+	var items []string
+	for _, key := range sortedKeys(m) {
+		if key == lastAppliedAnnotation {
+			continue
+		}
+		items = append(items, key+"="+oneLine(m[key]))
+	}
+	w.list(level, title, items)
+}
+
+// resources writes a resource list such as limits with one resource per line
+func (w *describeWriter) resources(level int, title string, list corev1.ResourceList) {
+	if len(list) == 0 {
+		return
+	}
+	w.line(level, "%s:", title)
+	for _, name := range sortedResourceNames(list) {
+		q := list[name]
+		w.line(level+1, "%s:\t%s", name, q.String())
+	}
+}
+
+func (w *describeWriter) String() string {
+	w.tw.Flush()
+	return w.buf.String()
+}
+
+func describePod(w *describeWriter, pod *corev1.Pod) {
+	w.line(0, "Name:\t%s", pod.Name)
+	w.line(0, "Namespace:\t%s", pod.Namespace)
+	if pod.Spec.Priority != nil {
+		w.line(0, "Priority:\t%d", *pod.Spec.Priority)
+	}
+	if pod.Spec.PriorityClassName != "" {
+		w.line(0, "Priority Class Name:\t%s", pod.Spec.PriorityClassName)
+	}
+	w.line(0, "Service Account:\t%s", orNone(pod.Spec.ServiceAccountName))
+	node := "<none>"
+	if pod.Spec.NodeName != "" {
+		node = pod.Spec.NodeName
+		if pod.Status.HostIP != "" {
+			node += "/" + pod.Status.HostIP
+		}
+	}
+	w.line(0, "Node:\t%s", node)
+	if pod.Status.StartTime != nil {
+		w.line(0, "Start Time:\t%s", formatTimestamp(*pod.Status.StartTime))
+	}
+	w.stringMap(0, "Labels", pod.Labels)
+	w.stringMap(0, "Annotations", pod.Annotations)
+	if pod.DeletionTimestamp != nil {
+		w.line(0, "Status:\tTerminating (lasts %s)", FormatAge(pod.DeletionTimestamp.Time))
+		if pod.DeletionGracePeriodSeconds != nil {
+			w.line(0, "Termination Grace Period:\t%ds", *pod.DeletionGracePeriodSeconds)
+		}
+	} else {
+		w.line(0, "Status:\t%s", pod.Status.Phase)
+	}
+	if pod.Status.Reason != "" {
+		w.line(0, "Reason:\t%s", pod.Status.Reason)
+	}
+	if pod.Status.Message != "" {
+		w.line(0, "Message:\t%s", oneLine(pod.Status.Message))
+	}
+	w.line(0, "IP:\t%s", orNone(pod.Status.PodIP))
+	if len(pod.Status.PodIPs) == 0 {
+		w.line(0, "IPs:\t<none>")
+	} else {
+		w.line(0, "IPs:")
+		for _, ip := range pod.Status.PodIPs {
+			w.line(1, "IP:\t%s", ip.IP)
+		}
+	}
+	describeControllerOf(w, pod)
+
+	if len(pod.Spec.InitContainers) > 0 {
+		w.line(0, "Init Containers:")
+		describeContainers(w, 0, pod.Spec.InitContainers, pod.Status.InitContainerStatuses)
+	}
+	w.line(0, "Containers:")
+	describeContainers(w, 0, pod.Spec.Containers, pod.Status.ContainerStatuses)
+
+	if len(pod.Status.Conditions) > 0 {
+		w.line(0, "Conditions:")
+		w.line(1, "Type\tStatus")
+		for _, c := range pod.Status.Conditions {
+			w.line(1, "%s\t%s", c.Type, c.Status)
+		}
+	}
+	describeVolumes(w, 0, pod.Spec.Volumes)
+	w.line(0, "QoS Class:\t%s", orNone(string(pod.Status.QOSClass)))
+	w.stringMap(0, "Node-Selectors", pod.Spec.NodeSelector)
+	var tolerations []string
+	for _, t := range pod.Spec.Tolerations {
+		tolerations = append(tolerations, formatToleration(t))
+	}
+	w.list(0, "Tolerations", tolerations)
+}
+
+// describeContainers writes containers below a heading at level, with the
+// state from statuses if they are of a pod rather than a template
+func describeContainers(w *describeWriter, level int, containers []corev1.Container, statuses []corev1.ContainerStatus) {
+	byName := make(map[string]corev1.ContainerStatus, len(statuses))
+	for _, s := range statuses {
+		byName[s.Name] = s
+	}
+	for _, c := range containers {
+		w.line(level+1, "%s:", c.Name)
+		status, hasStatus := byName[c.Name]
+		if hasStatus && status.ContainerID != "" {
+			w.line(level+2, "Container ID:\t%s", status.ContainerID)
+		}
+		w.line(level+2, "Image:\t%s", orNone(c.Image))
+		if hasStatus && status.ImageID != "" {
+			w.line(level+2, "Image ID:\t%s", status.ImageID)
+		}
+		var ports []string
+		for _, p := range c.Ports {
+			ports = append(ports, fmt.Sprintf("%d/%s", p.ContainerPort, p.Protocol))
+		}
+		w.line(level+2, "Ports:\t%s", orNone(strings.Join(ports, ", ")))
+		if len(c.Command) > 0 {
+			w.line(level+2, "Command:")
+			for _, arg := range c.Command {
+				w.line(level+3, "%s", oneLine(arg))
+			}
+		}
+		if len(c.Args) > 0 {
+			w.line(level+2, "Args:")
+			for _, arg := range c.Args {
+				w.line(level+3, "%s", oneLine(arg))
+			}
+		}
+		if hasStatus {
+			describeContainerState(w, level+2, "State", status.State)
+			if last := status.LastTerminationState; last.Running != nil || last.Waiting != nil || last.Terminated != nil {
+				describeContainerState(w, level+2, "Last State", last)
+			}
+			w.line(level+2, "Ready:\t%t", status.Ready)
+			w.line(level+2, "Restart Count:\t%d", status.RestartCount)
+		}
+		w.resources(level+2, "Limits", c.Resources.Limits)
+		w.resources(level+2, "Requests", c.Resources.Requests)
+		if c.LivenessProbe != nil {
+			w.line(level+2, "Liveness:\t%s", formatProbe(c.LivenessProbe))
+		}
+		if c.ReadinessProbe != nil {
+			w.line(level+2, "Readiness:\t%s", formatProbe(c.ReadinessProbe))
+		}
+		if c.StartupProbe != nil {
+			w.line(level+2, "Startup:\t%s", formatProbe(c.StartupProbe))
+		}
+		describeEnv(w, level+2, c)
+		var mounts []string
+		for _, m := range c.VolumeMounts {
+			mode := "rw"
+			if m.ReadOnly {
+				mode = "ro"
+			}
+			mount := fmt.Sprintf("%s from %s (%s)", m.MountPath, m.Name, mode)
+			if m.SubPath != "" {
+				mount = fmt.Sprintf("%s from %s (%s,path=%q)", m.MountPath, m.Name, mode, m.SubPath)
+			}
+			mounts = append(mounts, mount)
+		}
+		w.list(level+2, "Mounts", mounts)
+	}
+}
+
+func describeContainerState(w *describeWriter, level int, label string, state corev1.ContainerState) {
+	switch {
+	case state.Running != nil:
+		w.line(level, "%s:\tRunning", label)
+		w.line(level+1, "Started:\t%s", formatTimestamp(state.Running.StartedAt))
+	case state.Terminated != nil:
+		t := state.Terminated
+		w.line(level, "%s:\tTerminated", label)
+		if t.Reason != "" {
+			w.line(level+1, "Reason:\t%s", t.Reason)
+		}
+		if t.Message != "" {
+			w.line(level+1, "Message:\t%s", oneLine(t.Message))
+		}
+		w.line(level+1, "Exit Code:\t%d", t.ExitCode)
+		if t.Signal != 0 {
+			w.line(level+1, "Signal:\t%d", t.Signal)
+		}
+		w.line(level+1, "Started:\t%s", formatTimestamp(t.StartedAt))
+		w.line(level+1, "Finished:\t%s", formatTimestamp(t.FinishedAt))
+	default:
+		w.line(level, "%s:\tWaiting", label)
+		if state.Waiting != nil {
+			if state.Waiting.Reason != "" {
+				w.line(level+1, "Reason:\t%s", state.Waiting.Reason)
+			}
+			if state.Waiting.Message != "" {
+				w.line(level+1, "Message:\t%s", oneLine(state.Waiting.Message))
+			}
+		}
+	}
+}
+
+// formatProbe renders a probe like kubectl, e.g.
+// "http-get http://:8080/healthz delay=0s timeout=1s period=10s #success=1 #failure=3"
+func formatProbe(p *corev1.Probe) string {
+	action := "unknown"
+	switch {
+	case p.HTTPGet != nil:
+		scheme := strings.ToLower(string(p.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		action = fmt.Sprintf("http-get %s://%s:%s%s", scheme, p.HTTPGet.Host, p.HTTPGet.Port.String(), p.HTTPGet.Path)
+	case p.TCPSocket != nil:
+		action = fmt.Sprintf("tcp-socket %s:%s", p.TCPSocket.Host, p.TCPSocket.Port.String())
+	case p.Exec != nil:
+		action = fmt.Sprintf("exec [%s]", strings.Join(p.Exec.Command, " "))
+	case p.GRPC != nil:
+		action = fmt.Sprintf("grpc <pod>:%d", p.GRPC.Port)
+		if p.GRPC.Service != nil && *p.GRPC.Service != "" {
+			action += " " + *p.GRPC.Service
+		}
+	}
+	return fmt.Sprintf("%s delay=%ds timeout=%ds period=%ds #success=%d #failure=%d",
+		action, p.InitialDelaySeconds, p.TimeoutSeconds, p.PeriodSeconds, p.SuccessThreshold, p.FailureThreshold)
+}
+
+func describeEnv(w *describeWriter, level int, c corev1.Container) {
+	if len(c.EnvFrom) > 0 {
+		w.line(level, "Environment Variables from:")
+		for _, from := range c.EnvFrom {
+			switch {
+			case from.ConfigMapRef != nil:
+				w.line(level+1, "%s\tConfigMap\tPrefix: %s\tOptional: %t", from.ConfigMapRef.Name, from.Prefix, isTrue(from.ConfigMapRef.Optional))
+			case from.SecretRef != nil:
+				w.line(level+1, "%s\tSecret\tPrefix: %s\tOptional: %t", from.SecretRef.Name, from.Prefix, isTrue(from.SecretRef.Optional))
+			}
+		}
+	}
+	if len(c.Env) == 0 {
+		w.line(level, "Environment:\t<none>")
+		return
+	}
+	w.line(level, "Environment:")
+	for _, e := range c.Env {
+		from := e.ValueFrom
+		switch {
+		case from == nil:
+			w.line(level+1, "%s:\t%s", e.Name, oneLine(e.Value))
+		case from.FieldRef != nil:
+			w.line(level+1, "%s:\t (%s:%s)", e.Name, from.FieldRef.APIVersion, from.FieldRef.FieldPath)
+		case from.ResourceFieldRef != nil:
+			w.line(level+1, "%s:\t%s (%s)", e.Name, from.ResourceFieldRef.ContainerName, from.ResourceFieldRef.Resource)
+		case from.SecretKeyRef != nil:
+			w.line(level+1, "%s:\t<set to the key '%s' in secret '%s'>\tOptional: %t", e.Name, from.SecretKeyRef.Key, from.SecretKeyRef.Name, isTrue(from.SecretKeyRef.Optional))
+		case from.ConfigMapKeyRef != nil:
+			w.line(level+1, "%s:\t<set to the key '%s' of config map '%s'>\tOptional: %t", e.Name, from.ConfigMapKeyRef.Key, from.ConfigMapKeyRef.Name, isTrue(from.ConfigMapKeyRef.Optional))
+		}
+	}
+}
+
+func describeVolumes(w *describeWriter, level int, volumes []corev1.Volume) {
+	if len(volumes) == 0 {
+		w.line(level, "Volumes:\t<none>")
+		return
+	}
+	w.line(level, "Volumes:")
+	for _, v := range volumes {
+		w.line(level+1, "%s:", v.Name)
+		kind, details := volumeSource(v.VolumeSource)
+		w.line(level+2, "Type:\t%s", kind)
+		for _, d := range details {
+			w.line(level+2, "%s:\t%s", d[0], d[1])
+		}
+	}
+}
+
+// volumeSource names the type of a volume and its notable settings
+func volumeSource(src corev1.VolumeSource) (string, [][2]string) {
+	switch {
+	case src.EmptyDir != nil:
+		limit := "<unset>"
+		if src.EmptyDir.SizeLimit != nil {
+			limit = src.EmptyDir.SizeLimit.String()
+		}
+		return "EmptyDir (a temporary directory that shares a pod's lifetime)", [][2]string{
+			{"Medium", string(src.EmptyDir.Medium)},
+			{"SizeLimit", limit},
+		}
+	case src.HostPath != nil:
+		hostPathType := ""
+		if src.HostPath.Type != nil {
+			hostPathType = string(*src.HostPath.Type)
+		}
+		return "HostPath (bare host directory volume)", [][2]string{
+			{"Path", src.HostPath.Path},
+			{"HostPathType", hostPathType},
+		}
+	case src.ConfigMap != nil:
+		return "ConfigMap (a volume populated by a ConfigMap)", [][2]string{
+			{"Name", src.ConfigMap.Name},
+			{"Optional", fmt.Sprint(isTrue(src.ConfigMap.Optional))},
+		}
+	case src.Secret != nil:
+		return "Secret (a volume populated by a Secret)", [][2]string{
+			{"SecretName", src.Secret.SecretName},
+			{"Optional", fmt.Sprint(isTrue(src.Secret.Optional))},
+		}
+	case src.PersistentVolumeClaim != nil:
+		return "PersistentVolumeClaim (a reference to a PersistentVolumeClaim in the same namespace)", [][2]string{
+			{"ClaimName", src.PersistentVolumeClaim.ClaimName},
+			{"ReadOnly", fmt.Sprint(src.PersistentVolumeClaim.ReadOnly)},
+		}
+	case src.Projected != nil:
+		var sources []string
+		for _, p := range src.Projected.Sources {
+			switch {
+			case p.ServiceAccountToken != nil:
+				sources = append(sources, "ServiceAccountToken")
+			case p.ConfigMap != nil:
+				sources = append(sources, "ConfigMap "+p.ConfigMap.Name)
+			case p.Secret != nil:
+				sources = append(sources, "Secret "+p.Secret.Name)
+			case p.DownwardAPI != nil:
+				sources = append(sources, "DownwardAPI")
+			case p.ClusterTrustBundle != nil:
+				sources = append(sources, "ClusterTrustBundle")
+			}
+		}
+		return "Projected (a volume that contains injected data from multiple sources)", [][2]string{
+			{"Sources", strings.Join(sources, ", ")},
+		}
+	case src.DownwardAPI != nil:
+		return "DownwardAPI (a volume populated by information about the pod)", nil
+	case src.NFS != nil:
+		return "NFS (an NFS mount that lasts the lifetime of a pod)", [][2]string{
+			{"Server", src.NFS.Server},
+			{"Path", src.NFS.Path},
+			{"ReadOnly", fmt.Sprint(src.NFS.ReadOnly)},
+		}
+	case src.CSI != nil:
+		return "CSI (a Container Storage Interface (CSI) volume source)", [][2]string{
+			{"Driver", src.CSI.Driver},
+			{"ReadOnly", fmt.Sprint(isTrue(src.CSI.ReadOnly))},
+		}
+	case src.Ephemeral != nil:
+		return "EphemeralVolume (an inline specification for a volume that gets created and deleted with the pod)", nil
+	}
+	return "<unknown>", nil
+}
+
+// formatToleration renders a toleration like kubectl, e.g.
+// "node.kubernetes.io/not-ready:NoExecute op=Exists for 300s"
+func formatToleration(t corev1.Toleration) string {
+	s := t.Key
+	if t.Value != "" {
+		s += "=" + t.Value
+	}
+	if t.Effect != "" {
+		s += ":" + string(t.Effect)
+	}
+	if t.Operator == corev1.TolerationOpExists && t.Value == "" {
+		if s != "" {
+			s += " "
+		}
+		s += "op=Exists"
+	}
+	if t.TolerationSeconds != nil {
+		s += fmt.Sprintf(" for %ds", *t.TolerationSeconds)
+	}
+	return s
+}
+
+func describeControllerOf(w *describeWriter, obj metav1.Object) {
+	if ref := metav1.GetControllerOf(obj); ref != nil {
+		w.line(0, "Controlled By:\t%s/%s", ref.Kind, ref.Name)
+	}
+}
+
+func describePodTemplate(w *describeWriter, template corev1.PodTemplateSpec) {
+	w.line(0, "Pod Template:")
+	w.stringMap(1, "Labels", template.Labels)
+	if len(template.Annotations) > 0 {
+		w.stringMap(1, "Annotations", template.Annotations)
+	}
+	if template.Spec.ServiceAccountName != "" {
+		w.line(1, "Service Account:\t%s", template.Spec.ServiceAccountName)
+	}
+	if len(template.Spec.InitContainers) > 0 {
+		w.line(1, "Init Containers:")
+		describeContainers(w, 1, template.Spec.InitContainers, nil)
+	}
+	w.line(1, "Containers:")
+	describeContainers(w, 1, template.Spec.Containers, nil)
+	describeVolumes(w, 1, template.Spec.Volumes)
+}
+
+func (k *KubeClient) describeDeployment(ctx context.Context, w *describeWriter, d *appsv1.Deployment) {
+	w.line(0, "Name:\t%s", d.Name)
+	w.line(0, "Namespace:\t%s", d.Namespace)
+	w.line(0, "CreationTimestamp:\t%s", formatTimestamp(d.CreationTimestamp))
+	w.stringMap(0, "Labels", d.Labels)
+	w.stringMap(0, "Annotations", d.Annotations)
+	w.line(0, "Selector:\t%s", formatLabelSelector(d.Spec.Selector))
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	w.line(0, "Replicas:\t%d desired | %d updated | %d total | %d available | %d unavailable",
+		desired, d.Status.UpdatedReplicas, d.Status.Replicas, d.Status.AvailableReplicas, d.Status.UnavailableReplicas)
+	w.line(0, "StrategyType:\t%s", d.Spec.Strategy.Type)
+	w.line(0, "MinReadySeconds:\t%d", d.Spec.MinReadySeconds)
+	if ru := d.Spec.Strategy.RollingUpdate; ru != nil {
+		w.line(0, "RollingUpdateStrategy:\t%s max unavailable, %s max surge",
+			intOrStringOr(ru.MaxUnavailable, "25%"), intOrStringOr(ru.MaxSurge, "25%"))
+	}
+	describePodTemplate(w, d.Spec.Template)
+	if len(d.Status.Conditions) > 0 {
+		w.line(0, "Conditions:")
+		w.line(1, "Type\tStatus\tReason")
+		w.line(1, "----\t------\t------")
+		for _, c := range d.Status.Conditions {
+			w.line(1, "%s\t%s\t%s", c.Type, c.Status, c.Reason)
+		}
+	}
+
+	_, replicaSets, err := k.deploymentReplicaSets(ctx, d.Namespace, d.Name)
+	if err != nil {
+		w.line(0, "OldReplicaSets:\t<unknown: %v>", err)
+		w.line(0, "NewReplicaSet:\t<unknown>")
+		return
+	}
+	newRS := "<none>"
+	var old []string
+	for _, rs := range replicaSets {
+		created := int32(0)
+		if rs.Spec.Replicas != nil {
+			created = *rs.Spec.Replicas
+		}
+		entry := fmt.Sprintf("%s (%d/%d replicas created)", rs.Name, rs.Status.Replicas, created)
+		switch {
+		case rs.Annotations[revisionAnnotation] == d.Annotations[revisionAnnotation]:
+			newRS = entry
+		case rs.Status.Replicas > 0 || created > 0:
+			old = append(old, entry)
+		}
+	}
+	w.line(0, "OldReplicaSets:\t%s", orNone(strings.Join(old, ", ")))
+	w.line(0, "NewReplicaSet:\t%s", newRS)
+}
+
+func (k *KubeClient) describeService(ctx context.Context, w *describeWriter, svc *corev1.Service) {
+	w.line(0, "Name:\t%s", svc.Name)
+	w.line(0, "Namespace:\t%s", svc.Namespace)
+	w.stringMap(0, "Labels", svc.Labels)
+	w.stringMap(0, "Annotations", svc.Annotations)
+	var selector []string
+	for _, key := range sortedKeys(svc.Spec.Selector) {
+		selector = append(selector, key+"="+svc.Spec.Selector[key])
+	}
+	w.line(0, "Selector:\t%s", orNone(strings.Join(selector, ",")))
+	w.line(0, "Type:\t%s", svc.Spec.Type)
+	if svc.Spec.IPFamilyPolicy != nil {
+		w.line(0, "IP Family Policy:\t%s", *svc.Spec.IPFamilyPolicy)
+	}
+	if len(svc.Spec.IPFamilies) > 0 {
+		var families []string
+		for _, f := range svc.Spec.IPFamilies {
+			families = append(families, string(f))
+		}
+		w.line(0, "IP Families:\t%s", strings.Join(families, ","))
+	}
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		w.line(0, "External Name:\t%s", svc.Spec.ExternalName)
+	} else {
+		w.line(0, "IP:\t%s", orNone(svc.Spec.ClusterIP))
+		w.line(0, "IPs:\t%s", orNone(strings.Join(svc.Spec.ClusterIPs, ",")))
+	}
+	if len(svc.Spec.ExternalIPs) > 0 {
+		w.line(0, "External IPs:\t%s", strings.Join(svc.Spec.ExternalIPs, ","))
+	}
+	if ingress := serviceExternalIPs(svc); svc.Spec.Type == corev1.ServiceTypeLoadBalancer && ingress != "" {
+		w.line(0, "LoadBalancer Ingress:\t%s", ingress)
+	}
+
+	endpoints, err := k.clientset.CoreV1().Endpoints(svc.Namespace).Get(ctx, svc.Name, metav1.GetOptions{})
+	if err != nil {
+		endpoints = nil
+	}
+	for _, p := range svc.Spec.Ports {
+		name := p.Name
+		if name == "" {
+			name = "<unset>"
+		}
+		w.line(0, "Port:\t%s\t%d/%s", name, p.Port, p.Protocol)
+		target := p.TargetPort
+		if target.Type == intstr.Int && target.IntVal == 0 {
+			target = intstr.FromInt32(p.Port)
+		}
+		w.line(0, "TargetPort:\t%s/%s", target.String(), p.Protocol)
+		if p.NodePort != 0 {
+			w.line(0, "NodePort:\t%s\t%d/%s", name, p.NodePort, p.Protocol)
+		}
+		if svc.Spec.Type != corev1.ServiceTypeExternalName {
+			w.line(0, "Endpoints:\t%s", formatEndpoints(endpoints, p.Name))
+		}
+	}
+	w.line(0, "Session Affinity:\t%s", svc.Spec.SessionAffinity)
+	if svc.Spec.ExternalTrafficPolicy != "" {
+		w.line(0, "External Traffic Policy:\t%s", svc.Spec.ExternalTrafficPolicy)
+	}
+	if svc.Spec.InternalTrafficPolicy != nil {
+		w.line(0, "Internal Traffic Policy:\t%s", *svc.Spec.InternalTrafficPolicy)
+	}
+}
+
+// formatEndpoints lists the ready addresses behind a service port, showing
+// the first few like kubectl does
+func formatEndpoints(endpoints *corev1.Endpoints, portName string) string {
+	if endpoints == nil {
+		return "<none>"
+	}
+	const shown = 3
+	var addrs []string
+	for _, subset := range endpoints.Subsets {
+		for _, port := range subset.Ports {
+			if port.Name != portName {
+				continue
+			}
+			for _, addr := range subset.Addresses {
+				addrs = append(addrs, fmt.Sprintf("%s:%d", addr.IP, port.Port))
+			}
+		}
+	}
+	if len(addrs) > shown {
+		return fmt.Sprintf("%s + %d more...", strings.Join(addrs[:shown], ","), len(addrs)-shown)
+	}
+	return orNone(strings.Join(addrs, ","))
+}
+
+func (k *KubeClient) describeNode(ctx context.Context, w *describeWriter, node *corev1.Node) {
+	w.line(0, "Name:\t%s", node.Name)
+	var roles []string
+	for key := range node.Labels {
+		if role, ok := strings.CutPrefix(key, "node-role.kubernetes.io/"); ok && role != "" {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+	w.line(0, "Roles:\t%s", orNone(strings.Join(roles, ",")))
+	w.stringMap(0, "Labels", node.Labels)
+	w.stringMap(0, "Annotations", node.Annotations)
+	w.line(0, "CreationTimestamp:\t%s", formatTimestamp(node.CreationTimestamp))
+	var taints []string
+	for _, t := range node.Spec.Taints {
+		taint := t.Key
+		if t.Value != "" {
+			taint += "=" + t.Value
+		}
+		taints = append(taints, taint+":"+string(t.Effect))
+	}
+	w.list(0, "Taints", taints)
+	w.line(0, "Unschedulable:\t%t", node.Spec.Unschedulable)
+
+	if len(node.Status.Conditions) > 0 {
+		w.line(0, "Conditions:")
+		w.line(1, "Type\tStatus\tLastHeartbeatTime\tLastTransitionTime\tReason\tMessage")
+		w.line(1, "----\t------\t-----------------\t------------------\t------\t-------")
+		for _, c := range node.Status.Conditions {
+			w.line(1, "%s\t%s\t%s\t%s\t%s\t%s", c.Type, c.Status,
+				formatTimestamp(c.LastHeartbeatTime), formatTimestamp(c.LastTransitionTime), c.Reason, oneLine(c.Message))
+		}
+	}
+	w.line(0, "Addresses:")
+	for _, addr := range node.Status.Addresses {
+		w.line(1, "%s:\t%s", addr.Type, addr.Address)
+	}
+	w.resources(0, "Capacity", node.Status.Capacity)
+	w.resources(0, "Allocatable", node.Status.Allocatable)
+	info := node.Status.NodeInfo
+	w.line(0, "System Info:")
+	w.line(1, "Machine ID:\t%s", info.MachineID)
+	w.line(1, "System UUID:\t%s", info.SystemUUID)
+	w.line(1, "Boot ID:\t%s", info.BootID)
+	w.line(1, "Kernel Version:\t%s", info.KernelVersion)
+	w.line(1, "OS Image:\t%s", info.OSImage)
+	w.line(1, "Operating System:\t%s", info.OperatingSystem)
+	w.line(1, "Architecture:\t%s", info.Architecture)
+	w.line(1, "Container Runtime Version:\t%s", info.ContainerRuntimeVersion)
+	w.line(1, "Kubelet Version:\t%s", info.KubeletVersion)
+	w.line(0, "PodCIDR:\t%s", orNone(node.Spec.PodCIDR))
+	w.line(0, "PodCIDRs:\t%s", orNone(strings.Join(node.Spec.PodCIDRs, ",")))
+	if node.Spec.ProviderID != "" {
+		w.line(0, "ProviderID:\t%s", node.Spec.ProviderID)
+	}
+
+	selector := fields.AndSelectors(
+		fields.OneTermEqualSelector("spec.nodeName", node.Name),
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+	)
+	pods, err := k.clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{FieldSelector: selector.String()})
+	if err != nil {
+		w.line(0, "Non-terminated Pods:\t<unknown: %v>", err)
+		return
+	}
+	allocatable := node.Status.Allocatable
+	if len(allocatable) == 0 {
+		allocatable = node.Status.Capacity
+	}
+	w.line(0, "Non-terminated Pods:\t(%d in total)", len(pods.Items))
+	w.line(1, "Namespace\tName\tCPU Requests\tCPU Limits\tMemory Requests\tMemory Limits\tAge")
+	w.line(1, "---------\t----\t------------\t----------\t---------------\t-------------\t---")
+	totalRequests, totalLimits := corev1.ResourceList{}, corev1.ResourceList{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		requests, limits := podRequestsAndLimits(pod)
+		addResources(totalRequests, requests)
+		addResources(totalLimits, limits)
+		w.line(1, "%s\t%s\t%s\t%s\t%s\t%s\t%s", pod.Namespace, pod.Name,
+			formatAllocation(requests, allocatable, corev1.ResourceCPU),
+			formatAllocation(limits, allocatable, corev1.ResourceCPU),
+			formatAllocation(requests, allocatable, corev1.ResourceMemory),
+			formatAllocation(limits, allocatable, corev1.ResourceMemory),
+			FormatAge(pod.CreationTimestamp.Time))
+	}
+	w.line(0, "Allocated resources:")
+	w.line(1, "Resource\tRequests\tLimits")
+	w.line(1, "--------\t--------\t------")
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage} {
+		w.line(1, "%s\t%s\t%s", name, formatAllocation(totalRequests, allocatable, name), formatAllocation(totalLimits, allocatable, name))
+	}
+}
+
+// podRequestsAndLimits adds up the resources of a pod's containers; init
+// containers run one at a time, so only the largest of them counts if it
+// exceeds the sum
+func podRequestsAndLimits(pod *corev1.Pod) (corev1.ResourceList, corev1.ResourceList) {
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		addResources(requests, c.Resources.Requests)
+		addResources(limits, c.Resources.Limits)
+	}
+	for _, c := range pod.Spec.InitContainers {
+		maxResources(requests, c.Resources.Requests)
+		maxResources(limits, c.Resources.Limits)
+	}
+	addResources(requests, pod.Spec.Overhead)
+	addResources(limits, pod.Spec.Overhead)
+	return requests, limits
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for name, q := range add {
+		sum := total[name]
+		sum.Add(q)
+		total[name] = sum
+	}
+}
+
+func maxResources(total, other corev1.ResourceList) {
+	for name, q := range other {
+		if current, ok := total[name]; !ok || q.Cmp(current) > 0 {
+			total[name] = q.DeepCopy()
+		}
+	}
+}
+
+// formatAllocation renders an amount of a resource with its share of what
+// the node can allocate, e.g. "250m (12%)"
+func formatAllocation(list, allocatable corev1.ResourceList, name corev1.ResourceName) string {
+	q := list[name]
+	total := allocatable[name]
+	percent := int64(0)
+	if total.MilliValue() > 0 {
+		percent = q.MilliValue() * 100 / total.MilliValue()
+	}
+	return fmt.Sprintf("%s (%d%%)", q.String(), percent)
+}
+
+func (k *KubeClient) describePersistentVolumeClaim(ctx context.Context, w *describeWriter, pvc *corev1.PersistentVolumeClaim) {
+	w.line(0, "Name:\t%s", pvc.Name)
+	w.line(0, "Namespace:\t%s", pvc.Namespace)
+	storageClass := ""
+	if pvc.Spec.StorageClassName != nil {
+		storageClass = *pvc.Spec.StorageClassName
+	}
+	w.line(0, "StorageClass:\t%s", storageClass)
+	if pvc.DeletionTimestamp != nil {
+		w.line(0, "Status:\tTerminating (lasts %s)", FormatAge(pvc.DeletionTimestamp.Time))
+	} else {
+		w.line(0, "Status:\t%s", pvc.Status.Phase)
+	}
+	w.line(0, "Volume:\t%s", pvc.Spec.VolumeName)
+	w.stringMap(0, "Labels", pvc.Labels)
+	w.stringMap(0, "Annotations", pvc.Annotations)
+	w.line(0, "Finalizers:\t[%s]", strings.Join(pvc.Finalizers, " "))
+	capacity := ""
+	if q, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		capacity = q.String()
+	}
+	w.line(0, "Capacity:\t%s", capacity)
+	var modes []string
+	for _, mode := range pvc.Status.AccessModes {
+		modes = append(modes, accessModeShort(mode))
+	}
+	w.line(0, "Access Modes:\t%s", strings.Join(modes, ","))
+	if pvc.Spec.VolumeMode != nil {
+		w.line(0, "VolumeMode:\t%s", *pvc.Spec.VolumeMode)
+	}
+	if ds := pvc.Spec.DataSource; ds != nil {
+		w.line(0, "DataSource:")
+		w.line(1, "Kind:\t%s", ds.Kind)
+		w.line(1, "Name:\t%s", ds.Name)
+	}
+
+	pods, err := k.clientset.CoreV1().Pods(pvc.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		w.line(0, "Used By:\t<unknown: %v>", err)
+		return
+	}
+	var usedBy []string
+	for _, pod := range pods.Items {
+		for _, v := range pod.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == pvc.Name {
+				usedBy = append(usedBy, pod.Name)
+				break
+			}
+		}
+	}
+	w.list(0, "Used By", usedBy)
+}
+
+// accessModeShort abbreviates an access mode like kubectl, e.g. "RWO"
+func accessModeShort(mode corev1.PersistentVolumeAccessMode) string {
+	switch mode {
+	case corev1.ReadWriteOnce:
+		return "RWO"
+	case corev1.ReadOnlyMany:
+		return "ROX"
+	case corev1.ReadWriteMany:
+		return "RWX"
+	case corev1.ReadWriteOncePod:
+		return "RWOP"
+	}
+	return string(mode)
+}
+
+func describeJob(w *describeWriter, job *batchv1.Job) {
+	w.line(0, "Name:\t%s", job.Name)
+	w.line(0, "Namespace:\t%s", job.Namespace)
+	w.line(0, "Selector:\t%s", formatLabelSelector(job.Spec.Selector))
+	w.stringMap(0, "Labels", job.Labels)
+	w.stringMap(0, "Annotations", job.Annotations)
+	describeControllerOf(w, job)
+	w.line(0, "Parallelism:\t%s", int32Or(job.Spec.Parallelism, "<unset>"))
+	w.line(0, "Completions:\t%s", int32Or(job.Spec.Completions, "<unset>"))
+	if job.Spec.CompletionMode != nil {
+		w.line(0, "Completion Mode:\t%s", *job.Spec.CompletionMode)
+	}
+	if job.Spec.Suspend != nil {
+		w.line(0, "Suspend:\t%t", *job.Spec.Suspend)
+	}
+	w.line(0, "Backoff Limit:\t%s", int32Or(job.Spec.BackoffLimit, "<unset>"))
+	if job.Spec.TTLSecondsAfterFinished != nil {
+		w.line(0, "TTL Seconds After Finished:\t%d", *job.Spec.TTLSecondsAfterFinished)
+	}
+	if job.Status.StartTime != nil {
+		w.line(0, "Start Time:\t%s", formatTimestamp(*job.Status.StartTime))
+	}
+	if job.Status.CompletionTime != nil {
+		w.line(0, "Completed At:\t%s", formatTimestamp(*job.Status.CompletionTime))
+		if job.Status.StartTime != nil {
+			w.line(0, "Duration:\t%s", FormatDuration(job.Status.CompletionTime.Sub(job.Status.StartTime.Time)))
+		}
+	}
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		w.line(0, "Active Deadline Seconds:\t%ds", *job.Spec.ActiveDeadlineSeconds)
+	}
+	ready := int32(0)
+	if job.Status.Ready != nil {
+		ready = *job.Status.Ready
+	}
+	w.line(0, "Pods Statuses:\t%d Active (%d Ready) / %d Succeeded / %d Failed",
+		job.Status.Active, ready, job.Status.Succeeded, job.Status.Failed)
+	describePodTemplate(w, job.Spec.Template)
+	if len(job.Status.Conditions) > 0 {
+		w.line(0, "Conditions:")
+		w.line(1, "Type\tStatus\tReason\tMessage")
+		w.line(1, "----\t------\t------\t-------")
+		for _, c := range job.Status.Conditions {
+			w.line(1, "%s\t%s\t%s\t%s", c.Type, c.Status, c.Reason, oneLine(c.Message))
+		}
+	}
+}
+
+// describeUnstructured lists the metadata of an object of any kind followed
+// by its other fields, like kubectl does for kinds it has no describer for
+func describeUnstructured(w *describeWriter, obj *unstructured.Unstructured) {
+	w.line(0, "Name:\t%s", obj.GetName())
+	if obj.GetNamespace() != "" {
+		w.line(0, "Namespace:\t%s", obj.GetNamespace())
+	}
+	w.stringMap(0, "Labels", obj.GetLabels())
+	w.stringMap(0, "Annotations", obj.GetAnnotations())
+	w.line(0, "API Version:\t%s", obj.GetAPIVersion())
+	w.line(0, "Kind:\t%s", obj.GetKind())
+	w.line(0, "CreationTimestamp:\t%s", formatTimestamp(obj.GetCreationTimestamp()))
+	describeControllerOf(w, obj)
+	content := obj.UnstructuredContent()
+	for _, key := range sortedKeys(content) {
+		if key == "apiVersion" || key == "kind" || key == "metadata" {
+			continue
+		}
+		describeValue(w, 0, key, content[key])
+	}
+}
+
+// describeValue writes a field of an unstructured object; the items of
+// lists are written one after another below the field
+func describeValue(w *describeWriter, level int, key string, value interface{}) {
+	label := fieldLabel(key)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			w.line(level, "%s:\t<none>", label)
+			return
+		}
+		w.line(level, "%s:", label)
+		for _, k := range sortedKeys(v) {
+			describeValue(w, level+1, k, v[k])
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			w.line(level, "%s:\t<none>", label)
+			return
+		}
+		w.line(level, "%s:", label)
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); ok {
+				for _, k := range sortedKeys(m) {
+					describeValue(w, level+1, k, m[k])
+				}
+				continue
+			}
+			w.line(level+1, "%s", oneLine(fmt.Sprint(item)))
+		}
+	case nil:
+		w.line(level, "%s:\t<nil>", label)
+	default:
+		w.line(level, "%s:\t%s", label, oneLine(fmt.Sprint(v)))
+	}
+}
+
+// fieldLabel turns a field name into a describe label, e.g. "containerPort"
+// into "Container Port"
+func fieldLabel(key string) string {
+	var b strings.Builder
+	runes := []rune(key)
+	for i, r := range runes {
+		if i == 0 {
+			b.WriteRune(unicode.ToUpper(r))
+			continue
+		}
+		if unicode.IsUpper(r) && unicode.IsLower(runes[i-1]) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// describeEvents lists the events that refer to obj, oldest first
+func (k *KubeClient) describeEvents(ctx context.Context, w *describeWriter, obj *unstructured.Unstructured) {
+	selector := fields.Set{
+		"involvedObject.kind": obj.GetKind(),
+		"involvedObject.name": obj.GetName(),
+	}
+	if obj.GetNamespace() != "" {
+		selector["involvedObject.namespace"] = obj.GetNamespace()
+	}
+	// The kubelet refers to nodes by name rather than uid
+	if obj.GetKind() != "Node" {
+		selector["involvedObject.uid"] = string(obj.GetUID())
+	}
+	list, err := k.clientset.CoreV1().Events(obj.GetNamespace()).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(selector).String(),
+	})
+	if err != nil {
+		w.line(0, "Events:\t<unknown: %v>", err)
+		return
+	}
+	if len(list.Items) == 0 {
+		w.line(0, "Events:\t<none>")
+		return
+	}
+	events := list.Items
+	sort.SliceStable(events, func(i, j int) bool { return eventLastSeen(events[i]).Before(eventLastSeen(events[j])) })
+	w.line(0, "Events:")
+	w.line(1, "Type\tReason\tAge\tFrom\tMessage")
+	w.line(1, "----\t------\t----\t----\t-------")
+	for _, e := range events {
+		w.line(1, "%s\t%s\t%s\t%s\t%s", e.Type, e.Reason, eventAge(e), eventSource(e), oneLine(e.Message))
+	}
+}
+
+// eventLastSeen returns when an event last occurred; events.k8s.io clients
+// set the event time and series instead of the timestamps
+func eventLastSeen(e corev1.Event) time.Time {
+	switch {
+	case e.Series != nil:
+		return e.Series.LastObservedTime.Time
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	}
+	return e.EventTime.Time
+}
+
+// eventAge renders how long ago an event occurred, with how often and since
+// when if it repeated, e.g. "2m (x4 over 10m)"
+func eventAge(e corev1.Event) string {
+	first := e.FirstTimestamp.Time
+	if first.IsZero() {
+		first = e.EventTime.Time
+	}
+	count := e.Count
+	if e.Series != nil {
+		count = e.Series.Count
+	}
+	last := FormatAge(eventLastSeen(e))
+	if count > 1 {
+		return fmt.Sprintf("%s (x%d over %s)", last, count, FormatAge(first))
+	}
+	return last
+}
+
+func eventSource(e corev1.Event) string {
+	source := e.Source.Component
+	if source == "" {
+		source = e.ReportingController
+	}
+	return source
+}
+
+func formatTimestamp(t metav1.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return t.Format(time.RFC1123Z)
+}
+
+func formatLabelSelector(selector *metav1.LabelSelector) string {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return "<invalid>"
+	}
+	return orNone(sel.String())
+}
+
+func intOrStringOr(v *intstr.IntOrString, fallback string) string {
+	if v == nil {
+		return fallback
+	}
+	return v.String()
+}
+
+func int32Or(v *int32, fallback string) string {
+	if v == nil {
+		return fallback
+	}
+	return fmt.Sprint(*v)
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+// oneLine keeps a value on its line so it cannot break the column layout
+func oneLine(s string) string {
+	return strings.NewReplacer("\r", "", "\n", " ", "\t", " ").Replace(strings.TrimSpace(s))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedResourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
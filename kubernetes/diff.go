@@ -0,0 +1,225 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// diffContext is how many unchanged lines surround every change in a diff
+const diffContext = 3
+
+// DryRunEdit sends an edit to the server as a dry run and returns a unified
+// diff from the checked out object to the object the server would store,
+// so defaulting and mutating admission are included. Nothing is persisted.
+// The diff is empty when the edit changes nothing.
+//
+// The dry run sends the same JSON merge patch, with the same field manager,
+// as ApplyEdit, so the diff shows exactly what confirming writes. A
+// server-side apply dry run would instead take ownership of every field in
+// the manifest, and could conflict with other managers or drop their fields
+// in ways the real write does not.
+func (k *KubeClient) DryRunEdit(ctx context.Context, obj *EditableObject, edited []byte) (string, error) {
+	if err := k.ensureWritable(); err != nil {
+		return "", err
+	}
+	changes, err := obj.patch(edited)
+	if err != nil || changes == nil {
+		return "", err
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode patch: %v", err)
+	}
+	client, err := k.resourceClient(ctx, obj.ResourceType, obj.Namespace)
+	if err != nil {
+		return "", err
+	}
+	result, err := client.Patch(ctx, obj.Name, types.MergePatchType, data, metav1.PatchOptions{
+		DryRun:       []string{metav1.DryRunAll},
+		FieldManager: fieldManager,
+	})
+	if err != nil {
+		return "", fmt.Errorf("dry run of %s/%s failed: %w", obj.ResourceType, obj.Name, err)
+	}
+
+	var live unstructured.Unstructured
+	if err := live.UnmarshalJSON(obj.base); err != nil {
+		return "", fmt.Errorf("failed to decode the original object: %v", err)
+	}
+	before, err := diffableYAML(&live)
+	if err != nil {
+		return "", err
+	}
+	after, err := diffableYAML(result)
+	if err != nil {
+		return "", err
+	}
+	return UnifiedDiff("live", "edited (server dry run)", before, after), nil
+}
+
+// diffableYAML renders an object without the fields every write changes
+func diffableYAML(obj *unstructured.Unstructured) (string, error) {
+	obj = obj.DeepCopy()
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(obj.Object, "status")
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s: %v", obj.GetName(), err)
+	}
+	out, err := yaml.JSONToYAML(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s: %v", obj.GetName(), err)
+	}
+	return string(out), nil
+}
+
+// diffLine is one line of a diff: ' ' unchanged, '-' removed or '+' added
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// UnifiedDiff returns the line diff of from and to in unified format, or ""
+// if they are equal
+func UnifiedDiff(fromName, toName, from, to string) string {
+	lines := diffLines(splitLines(from), splitLines(to))
+
+	// Line numbers in from and to before every diff line
+	fromPos := make([]int, len(lines)+1)
+	toPos := make([]int, len(lines)+1)
+	changed := false
+	for i, l := range lines {
+		fromPos[i+1], toPos[i+1] = fromPos[i], toPos[i]
+		if l.op != '+' {
+			fromPos[i+1]++
+		}
+		if l.op != '-' {
+			toPos[i+1]++
+		}
+		changed = changed || l.op != ' '
+	}
+	if !changed {
+		return ""
+	}
+
+	out := []string{"--- " + fromName, "+++ " + toName}
+	for i := 0; i < len(lines); {
+		for i < len(lines) && lines[i].op == ' ' {
+			i++
+		}
+		if i == len(lines) {
+			break
+		}
+		// A hunk runs until more than two contexts' worth of lines are unchanged
+		start := max(0, i-diffContext)
+		last := i
+		for j := i; j < len(lines); j++ {
+			if lines[j].op != ' ' {
+				last = j
+			} else if j-last > 2*diffContext {
+				break
+			}
+		}
+		end := min(len(lines), last+diffContext+1)
+
+		out = append(out, fmt.Sprintf("@@ -%s +%s @@",
+			hunkRange(fromPos[start], fromPos[end]-fromPos[start]),
+			hunkRange(toPos[start], toPos[end]-toPos[start])))
+		for _, l := range lines[start:end] {
+			out = append(out, string(l.op)+l.text)
+		}
+		i = end
+	}
+	return strings.Join(out, "\n")
+}
+
+// hunkRange formats the start,count of a hunk; empty ranges point at the
+// line before them
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// diffLines aligns two texts on their longest common subsequence of lines.
+// The common prefix and suffix are matched first, so the LCS table only
+// spans the lines in between and edits of large objects stay cheap.
+func diffLines(a, b []string) []diffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b)-prefix-suffix)
+	for _, text := range a[:prefix] {
+		lines = append(lines, diffLine{' ', text})
+	}
+	lines = append(lines, lcsDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{' ', text})
+	}
+	return lines
+}
+
+// lcsDiff aligns a and b on their longest common subsequence
+func lcsDiff(a, b []string) []diffLine {
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
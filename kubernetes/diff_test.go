@@ -0,0 +1,122 @@
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// numbered returns the lines "line 1" to "line n", with the lines listed in
+// replace swapped for the given text
+func numbered(n int, replace map[int]string) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		if text, ok := replace[i]; ok {
+			b.WriteString(text + "\n")
+			continue
+		}
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	return b.String()
+}
+
+func TestUnifiedDiffHunks(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		hunks    []string
+	}{
+		{
+			name: "equal",
+			from: numbered(5, nil),
+			to:   numbered(5, nil),
+		},
+		{
+			name: "both empty",
+		},
+		{
+			name:  "one line changed",
+			from:  numbered(10, nil),
+			to:    numbered(10, map[int]string{5: "changed"}),
+			hunks: []string{"@@ -2,7 +2,7 @@"},
+		},
+		{
+			name:  "change on the first line",
+			from:  numbered(10, nil),
+			to:    numbered(10, map[int]string{1: "changed"}),
+			hunks: []string{"@@ -1,4 +1,4 @@"},
+		},
+		{
+			name:  "change on the last line",
+			from:  numbered(10, nil),
+			to:    numbered(10, map[int]string{10: "changed"}),
+			hunks: []string{"@@ -7,4 +7,4 @@"},
+		},
+		{
+			name:  "added to an empty text",
+			from:  "",
+			to:    "a\nb\n",
+			hunks: []string{"@@ -0,0 +1,2 @@"},
+		},
+		{
+			name:  "everything removed",
+			from:  "a\nb\n",
+			to:    "",
+			hunks: []string{"@@ -1,2 +0,0 @@"},
+		},
+		{
+			name:  "lines appended",
+			from:  numbered(5, nil),
+			to:    numbered(5, nil) + "x\ny\n",
+			hunks: []string{"@@ -3,3 +3,5 @@"},
+		},
+		{
+			name:  "changes six lines apart share a hunk",
+			from:  numbered(20, nil),
+			to:    numbered(20, map[int]string{3: "x", 10: "y"}),
+			hunks: []string{"@@ -1,13 +1,13 @@"},
+		},
+		{
+			name:  "changes seven lines apart get their own hunks",
+			from:  numbered(20, nil),
+			to:    numbered(20, map[int]string{3: "x", 11: "y"}),
+			hunks: []string{"@@ -1,6 +1,6 @@", "@@ -8,7 +8,7 @@"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := UnifiedDiff("from", "to", tt.from, tt.to)
+			if tt.hunks == nil {
+				if diff != "" {
+					t.Fatalf("expected no diff, got:\n%s", diff)
+				}
+				return
+			}
+
+			lines := strings.Split(diff, "\n")
+			if len(lines) < 2 || lines[0] != "--- from" || lines[1] != "+++ to" {
+				t.Fatalf("missing file headers:\n%s", diff)
+			}
+			var hunks []string
+			for _, line := range lines {
+				if strings.HasPrefix(line, "@@") {
+					hunks = append(hunks, line)
+				}
+			}
+			if !reflect.DeepEqual(hunks, tt.hunks) {
+				t.Errorf("hunks = %q, want %q\n%s", hunks, tt.hunks, diff)
+			}
+		})
+	}
+}
+
+func TestDiffLinesKeepsCommonEnds(t *testing.T) {
+	a := []string{"a", "b", "c", "d", "e"}
+	b := []string{"a", "b", "x", "d", "e"}
+	want := []diffLine{{' ', "a"}, {' ', "b"}, {'-', "c"}, {'+', "x"}, {' ', "d"}, {' ', "e"}}
+	if got := diffLines(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffLines = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,147 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// EditableObject is an object checked out for editing. Manifest is the YAML
+// the user edits; managed fields and status are left out since they cannot
+// be changed through an edit.
+type EditableObject struct {
+	ResourceType string
+	Namespace    string
+	Name         string
+	Manifest     []byte
+
+	base            []byte
+	resourceVersion string
+}
+
+// invalidEditError is an edited manifest that cannot be applied as is
+type invalidEditError struct {
+	msg string
+}
+
+func (e *invalidEditError) Error() string { return e.msg }
+
+// IsEditInvalid reports whether an edit failed because the edited manifest
+// was rejected, so editing it again can fix it
+func IsEditInvalid(err error) bool {
+	var invalid *invalidEditError
+	return errors.As(err, &invalid) || apierrors.IsInvalid(err) || apierrors.IsBadRequest(err)
+}
+
+// IsEditConflict reports whether an edit failed because the object changed
+// on the server after it was checked out
+func IsEditConflict(err error) bool {
+	return apierrors.IsConflict(err)
+}
+
+// GetEditableObject fetches an object for editing
+func (k *KubeClient) GetEditableObject(ctx context.Context, resourceType, namespace, name string) (*EditableObject, error) {
+	client, err := k.resourceClient(ctx, resourceType, namespace)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s/%s: %v", resourceType, name, err)
+	}
+
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(obj.Object, "status")
+	base, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s/%s: %v", resourceType, name, err)
+	}
+	manifest, err := yaml.JSONToYAML(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s/%s: %v", resourceType, name, err)
+	}
+	return &EditableObject{
+		ResourceType:    resourceType,
+		Namespace:       obj.GetNamespace(),
+		Name:            name,
+		Manifest:        manifest,
+		base:            base,
+		resourceVersion: obj.GetResourceVersion(),
+	}, nil
+}
+
+// ApplyEdit patches the object with the changes between its checked out
+// manifest and edited. The patch carries the checked out resourceVersion,
+// so it fails with a conflict if the object changed in the meantime. It
+// reports whether there was anything to change.
+func (k *KubeClient) ApplyEdit(ctx context.Context, obj *EditableObject, edited []byte) (bool, error) {
+	if err := k.ensureWritable(); err != nil {
+		return false, err
+	}
+	changes, err := obj.patch(edited)
+	if err != nil || changes == nil {
+		return false, err
+	}
+	if err := k.mergePatch(ctx, obj.ResourceType, obj.Namespace, obj.Name, changes); err != nil {
+		return false, fmt.Errorf("failed to update %s/%s: %w", obj.ResourceType, obj.Name, err)
+	}
+	return true, nil
+}
+
+// patch computes the merge patch from the checked out manifest to edited,
+// or nil if nothing changed
+func (obj *EditableObject) patch(edited []byte) (map[string]interface{}, error) {
+	editedJSON, err := yaml.YAMLToJSON(edited)
+	if err != nil {
+		return nil, &invalidEditError{msg: fmt.Sprintf("invalid YAML: %v", err)}
+	}
+	var editedObj unstructured.Unstructured
+	if err := editedObj.UnmarshalJSON(editedJSON); err != nil {
+		return nil, &invalidEditError{msg: fmt.Sprintf("invalid object: %v", err)}
+	}
+	var baseObj unstructured.Unstructured
+	if err := baseObj.UnmarshalJSON(obj.base); err != nil {
+		return nil, fmt.Errorf("failed to decode the original object: %v", err)
+	}
+	if editedObj.GetAPIVersion() != baseObj.GetAPIVersion() || editedObj.GetKind() != baseObj.GetKind() ||
+		editedObj.GetName() != baseObj.GetName() || editedObj.GetNamespace() != baseObj.GetNamespace() {
+		return nil, &invalidEditError{msg: "apiVersion, kind, name and namespace cannot be changed"}
+	}
+
+	patch, err := jsonpatch.CreateMergePatch(obj.base, editedJSON)
+	if err != nil {
+		return nil, &invalidEditError{msg: fmt.Sprintf("failed to compute the changes: %v", err)}
+	}
+	var changes map[string]interface{}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode the changes: %v", err)
+	}
+	// Status is not part of the edit, and a changed resourceVersion is
+	// replaced with the checked out one below
+	delete(changes, "status")
+	if metadata, ok := changes["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "resourceVersion")
+		delete(metadata, "managedFields")
+		if len(metadata) == 0 {
+			delete(changes, "metadata")
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	metadata, _ := changes["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+		changes["metadata"] = metadata
+	}
+	metadata["resourceVersion"] = obj.resourceVersion
+	return changes, nil
+}
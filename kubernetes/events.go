@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ClusterEvent is an event as listed in the events view
+type ClusterEvent struct {
+	Type    string
+	Reason  string
+	Message string
+	Source  string
+	Count   int32
+	// LastSeen is when the event last occurred
+	LastSeen time.Time
+	// Object is the object the event is about
+	Object EventObject
+}
+
+// EventObject is the object an event refers to
+type EventObject struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// IsWarning reports whether the event is a Warning rather than Normal
+func (e ClusterEvent) IsWarning() bool {
+	return e.Type == corev1.EventTypeWarning
+}
+
+// ListEvents lists the events of a namespace, or of all namespaces, most
+// recent first
+func (k *KubeClient) ListEvents(ctx context.Context, namespace string) ([]ClusterEvent, error) {
+	ns, _ := normalizeNamespaceForList(namespace)
+	list, err := k.clientset.CoreV1().Events(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %v", err)
+	}
+
+	events := make([]ClusterEvent, 0, len(list.Items))
+	for _, e := range list.Items {
+		count := e.Count
+		if e.Series != nil {
+			count = e.Series.Count
+		}
+		if count == 0 {
+			count = 1
+		}
+		events = append(events, ClusterEvent{
+			Type:     e.Type,
+			Reason:   e.Reason,
+			Message:  oneLine(e.Message),
+			Source:   eventSource(e),
+			Count:    count,
+			LastSeen: eventLastSeen(e),
+			Object: EventObject{
+				APIVersion: e.InvolvedObject.APIVersion,
+				Kind:       e.InvolvedObject.Kind,
+				Namespace:  e.InvolvedObject.Namespace,
+				Name:       e.InvolvedObject.Name,
+			},
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].LastSeen.After(events[j].LastSeen) })
+	return events, nil
+}
+
+// ResourceForKind finds the resource name the table lists objects of a kind
+// under, e.g. "deployments" for apps/v1 Deployment
+func (k *KubeClient) ResourceForKind(ctx context.Context, apiVersion, kind string) (string, error) {
+	if apiVersion == "" {
+		apiVersion = "v1"
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return "", fmt.Errorf("invalid apiVersion %s: %v", apiVersion, err)
+	}
+	mapping, err := k.kindMapping(ctx, gv.WithKind(kind))
+	switch {
+	case ctx.Err() != nil:
+		return "", ctx.Err()
+	case meta.IsNoMatchError(err):
+		return "", fmt.Errorf("no resource of kind %s in %s", kind, apiVersion)
+	case err != nil:
+		return "", fmt.Errorf("failed to discover resources of %s: %v", apiVersion, err)
+	}
+	return mapping.Resource.Resource, nil
+}
@@ -0,0 +1,249 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+// shellCommand starts bash when the container has it and sh otherwise, in a
+// single exec so no failed attempt is left reading the terminal
+var shellCommand = []string{"/bin/sh", "-c", "command -v bash >/dev/null && exec bash || exec sh"}
+
+// fallbackShell is tried when the container has no /bin/sh
+var fallbackShell = []string{"ash"}
+
+// TerminalSize is the width and height of a terminal in cells
+type TerminalSize = remotecommand.TerminalSize
+
+// TerminalSizeQueue reports terminal resizes to a running exec session
+type TerminalSizeQueue = remotecommand.TerminalSizeQueue
+
+// ExecShell opens an interactive shell with a TTY in a container, preferring
+// bash over sh and trying ash when there is no /bin/sh. It returns when the
+// shell exits.
+func (k *KubeClient) ExecShell(ctx context.Context, namespace, pod, container string, stdin io.Reader, stdout io.Writer, sizes TerminalSizeQueue) error {
+	if err := k.ensureWritable(); err != nil {
+		return err
+	}
+	input := newExecInput(stdin, sizes)
+	defer input.close()
+
+	var lastErr error
+	for _, command := range [][]string{shellCommand, fallbackShell} {
+		attempt := input.attempt()
+		out := &countingWriter{w: stdout}
+		err := k.execTTY(ctx, namespace, pod, container, command, attempt, out, attempt.sizeQueue())
+		attempt.close()
+		// A shell that printed anything has started; whatever it exits with
+		// is the user's doing
+		if err == nil || out.written.Load() > 0 || !isShellNotFound(err) {
+			return err
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("no shell found in container %s (tried sh and ash): %v", container, lastErr)
+}
+
+// execTTY runs command in a container with stdin and a TTY attached. The
+// WebSocket protocol is preferred, falling back to SPDY for API servers
+// that cannot upgrade to it.
+func (k *KubeClient) execTTY(ctx context.Context, namespace, pod, container string, command []string, stdin io.Reader, stdout io.Writer, sizes TerminalSizeQueue) error {
+	req := k.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     true,
+			Stdout:    true,
+			TTY:       true,
+		}, scheme.ParameterCodec)
+
+	spdyExec, err := remotecommand.NewSPDYExecutor(k.config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create exec session: %v", err)
+	}
+	websocketExec, err := remotecommand.NewWebSocketExecutor(k.config, "GET", req.URL().String())
+	if err != nil {
+		return fmt.Errorf("failed to create exec session: %v", err)
+	}
+	executor, err := remotecommand.NewFallbackExecutor(websocketExec, spdyExec, httpstream.IsUpgradeFailure)
+	if err != nil {
+		return fmt.Errorf("failed to create exec session: %v", err)
+	}
+
+	return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:             stdin,
+		Stdout:            stdout,
+		Tty:               true,
+		TerminalSizeQueue: sizes,
+	})
+}
+
+// isShellNotFound reports whether an exec failed because the command does
+// not exist in the container. Runtimes exit with 126 or 127 for that and
+// name the missing file in the message.
+func isShellNotFound(err error) bool {
+	var exitErr exec.CodeExitError
+	if errors.As(err, &exitErr) && (exitErr.Code == 126 || exitErr.Code == 127) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "no such file or directory") || strings.Contains(msg, "executable file not found")
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w       io.Writer
+	written atomic.Int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.written.Add(int64(n))
+	return n, err
+}
+
+// execInput shares the terminal between exec attempts. Each attempt reads
+// input and resizes through its own execAttempt, which is closed when the
+// attempt ends, so the stream goroutines of a failed attempt cannot take
+// keystrokes or the terminal size meant for the next one.
+type execInput struct {
+	chunks   chan []byte
+	sizes    chan TerminalSize
+	done     chan struct{}
+	hasSizes bool
+
+	mu       sync.Mutex
+	lastSize *TerminalSize
+}
+
+func newExecInput(stdin io.Reader, sizes TerminalSizeQueue) *execInput {
+	in := &execInput{
+		chunks:   make(chan []byte),
+		sizes:    make(chan TerminalSize),
+		done:     make(chan struct{}),
+		hasSizes: sizes != nil,
+	}
+	go func() {
+		defer close(in.chunks)
+		for {
+			buf := make([]byte, 32*1024)
+			n, err := stdin.Read(buf)
+			if n > 0 {
+				select {
+				case in.chunks <- buf[:n]:
+				case <-in.done:
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	if sizes != nil {
+		go func() {
+			defer close(in.sizes)
+			for {
+				size := sizes.Next()
+				if size == nil {
+					return
+				}
+				in.mu.Lock()
+				in.lastSize = size
+				in.mu.Unlock()
+				select {
+				case in.sizes <- *size:
+				case <-in.done:
+					return
+				}
+			}
+		}()
+	}
+	return in
+}
+
+// attempt hands the input to the next exec attempt
+func (in *execInput) attempt() *execAttempt {
+	return &execAttempt{in: in, done: make(chan struct{})}
+}
+
+func (in *execInput) close() {
+	close(in.done)
+}
+
+// execAttempt is the input of one exec attempt. It reads like stdin and
+// queues resizes, starting with the latest known size, until it is closed.
+type execAttempt struct {
+	in        *execInput
+	done      chan struct{}
+	closeOnce sync.Once
+	pending   []byte
+	sizeSent  bool
+}
+
+func (a *execAttempt) Read(p []byte) (int, error) {
+	if len(a.pending) == 0 {
+		select {
+		case chunk, ok := <-a.in.chunks:
+			if !ok {
+				return 0, io.EOF
+			}
+			a.pending = chunk
+		case <-a.done:
+			return 0, io.EOF
+		}
+	}
+	n := copy(p, a.pending)
+	a.pending = a.pending[n:]
+	return n, nil
+}
+
+// Next returns the next terminal size, or nil once the attempt is closed
+func (a *execAttempt) Next() *TerminalSize {
+	if !a.sizeSent {
+		a.sizeSent = true
+		a.in.mu.Lock()
+		size := a.in.lastSize
+		a.in.mu.Unlock()
+		if size != nil {
+			return size
+		}
+	}
+	select {
+	case size, ok := <-a.in.sizes:
+		if !ok {
+			return nil
+		}
+		return &size
+	case <-a.done:
+		return nil
+	}
+}
+
+// sizeQueue returns the attempt as a TerminalSizeQueue, or nil when the
+// input has no terminal to take sizes from
+func (a *execAttempt) sizeQueue() TerminalSizeQueue {
+	if !a.in.hasSizes {
+		return nil
+	}
+	return a
+}
+
+func (a *execAttempt) close() {
+	a.closeOnce.Do(func() { close(a.done) })
+}
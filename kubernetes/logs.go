@@ -0,0 +1,193 @@
+package kubernetes
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultContainerAnnotation names the container kubectl picks when none is given
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// Container kinds reported in ContainerInfo
+const (
+	ContainerKindRegular   = "container"
+	ContainerKindInit      = "init"
+	ContainerKindEphemeral = "ephemeral"
+)
+
+// ContainerInfo describes a container of a pod that logs can be read from
+type ContainerInfo struct {
+	Name    string
+	Kind    string
+	State   string
+	Default bool
+}
+
+// LogOptions selects which logs GetPodLogs and StreamPodLogs return
+type LogOptions struct {
+	Container  string
+	Follow     bool
+	Previous   bool
+	Timestamps bool
+	// TailLines limits the output to the last lines; 0 returns everything
+	TailLines int64
+	// SinceSeconds and SinceTime are mutually exclusive; SinceTime wins
+	SinceSeconds int64
+	SinceTime    *time.Time
+}
+
+func (o LogOptions) podLogOptions() *corev1.PodLogOptions {
+	opts := &corev1.PodLogOptions{
+		Container:  o.Container,
+		Follow:     o.Follow,
+		Previous:   o.Previous,
+		Timestamps: o.Timestamps,
+	}
+	if o.TailLines > 0 {
+		tail := o.TailLines
+		opts.TailLines = &tail
+	}
+	if o.SinceTime != nil {
+		since := metav1.NewTime(*o.SinceTime)
+		opts.SinceTime = &since
+	} else if o.SinceSeconds > 0 {
+		seconds := o.SinceSeconds
+		opts.SinceSeconds = &seconds
+	}
+	return opts
+}
+
+// containerStateSummary renders a container status the way kubectl get shows it
+func containerStateSummary(status *corev1.ContainerStatus) string {
+	if status == nil {
+		return "Waiting"
+	}
+	switch {
+	case status.State.Running != nil:
+		return "Running"
+	case status.State.Waiting != nil:
+		if status.State.Waiting.Reason != "" {
+			return status.State.Waiting.Reason
+		}
+		return "Waiting"
+	case status.State.Terminated != nil:
+		if status.State.Terminated.Reason != "" {
+			return status.State.Terminated.Reason
+		}
+		return "Terminated"
+	}
+	return "Unknown"
+}
+
+func findContainerStatus(statuses []corev1.ContainerStatus, name string) *corev1.ContainerStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// GetPodContainers lists the regular, init and ephemeral containers of a pod,
+// in that order. The container kubectl would pick by default is marked.
+func (k *KubeClient) GetPodContainers(ctx context.Context, namespace, podName string) ([]ContainerInfo, error) {
+	pod, err := k.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s in namespace %s: %v", podName, namespace, err)
+	}
+
+	defaultName := pod.Annotations[defaultContainerAnnotation]
+	if defaultName == "" && len(pod.Spec.Containers) > 0 {
+		defaultName = pod.Spec.Containers[0].Name
+	}
+
+	containers := make([]ContainerInfo, 0, len(pod.Spec.Containers)+len(pod.Spec.InitContainers)+len(pod.Spec.EphemeralContainers))
+	for _, c := range pod.Spec.Containers {
+		containers = append(containers, ContainerInfo{
+			Name:    c.Name,
+			Kind:    ContainerKindRegular,
+			State:   containerStateSummary(findContainerStatus(pod.Status.ContainerStatuses, c.Name)),
+			Default: c.Name == defaultName,
+		})
+	}
+	for _, c := range pod.Spec.InitContainers {
+		containers = append(containers, ContainerInfo{
+			Name:  c.Name,
+			Kind:  ContainerKindInit,
+			State: containerStateSummary(findContainerStatus(pod.Status.InitContainerStatuses, c.Name)),
+		})
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		containers = append(containers, ContainerInfo{
+			Name:  c.Name,
+			Kind:  ContainerKindEphemeral,
+			State: containerStateSummary(findContainerStatus(pod.Status.EphemeralContainerStatuses, c.Name)),
+		})
+	}
+	return containers, nil
+}
+
+// GetPodLogs reads the logs selected by opts in one go. Follow is ignored; use
+// StreamPodLogs to keep reading new lines.
+func (k *KubeClient) GetPodLogs(ctx context.Context, namespace, podName string, opts LogOptions) (string, error) {
+	opts.Follow = false
+	req := k.clientset.CoreV1().Pods(namespace).GetLogs(podName, opts.podLogOptions())
+	podLogs, err := req.Stream(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs for pod %s in namespace %s: %v", podName, namespace, err)
+	}
+	defer podLogs.Close()
+
+	data, err := io.ReadAll(podLogs)
+	if err != nil {
+		return "", fmt.Errorf("failed to read logs for pod %s in namespace %s: %v", podName, namespace, err)
+	}
+	return string(data), nil
+}
+
+// LogLine is a single line delivered by StreamPodLogs. A line with Err set is
+// the last one sent before the channel is closed.
+type LogLine struct {
+	Text string
+	Err  error
+}
+
+// StreamPodLogs streams the logs selected by opts line by line. With Follow
+// set the stream stays open and delivers new lines as the container writes
+// them. The channel is closed once the stream ends or ctx is cancelled.
+func (k *KubeClient) StreamPodLogs(ctx context.Context, namespace, podName string, opts LogOptions) (<-chan LogLine, error) {
+	req := k.clientset.CoreV1().Pods(namespace).GetLogs(podName, opts.podLogOptions())
+	podLogs, err := req.Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream logs for pod %s in namespace %s: %v", podName, namespace, err)
+	}
+
+	lines := make(chan LogLine)
+	go func() {
+		defer close(lines)
+		defer podLogs.Close()
+
+		scanner := bufio.NewScanner(podLogs)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			select {
+			case lines <- LogLine{Text: scanner.Text()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil && ctx.Err() == nil {
+			select {
+			case lines <- LogLine{Err: fmt.Errorf("log stream for pod %s ended: %v", podName, err)}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return lines, nil
+}
@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	"context"
 	"fmt"
 	"log"
 )
@@ -13,14 +14,14 @@ func main() {
 	}
 
 	// Test connection
-	if err := client.TestConnection(); err != nil {
+	if err := client.TestConnection(context.Background()); err != nil {
 		log.Fatalf("Failed to connect to Kubernetes: %v", err)
 	}
 
 	fmt.Println("Successfully connected to Kubernetes cluster!")
 
 	// Get namespaces
-	namespaces, err := client.GetNamespaces()
+	namespaces, err := client.GetNamespaces(context.Background())
 	if err != nil {
 		log.Printf("Failed to get namespaces: %v", err)
 	} else {
@@ -28,7 +29,7 @@ func main() {
 	}
 
 	// Get API resources
-	resources, err := client.GetAPIResources()
+	resources, err := client.GetAPIResources(context.Background())
 	if err != nil {
 		log.Printf("Failed to get API resources: %v", err)
 	} else {
@@ -0,0 +1,163 @@
+package kubernetes
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nodeUnreachablePodReason is the pod reason the node controller sets when
+// the pod's node stops responding
+const nodeUnreachablePodReason = "NodeLost"
+
+// podSummary holds the READY, STATUS and RESTARTS columns of a pod
+type podSummary struct {
+	ready       string
+	status      string
+	restarts    int
+	lastRestart time.Time
+}
+
+// summarizePod derives the READY, STATUS and RESTARTS columns with the same
+// rules as kubectl's pod printer: container waiting and terminated reasons,
+// init container progress, sidecar containers, Terminating and the pod
+// reason (Evicted, NodeLost, ...).
+func summarizePod(pod *corev1.Pod) podSummary {
+	restarts := 0
+	sidecarRestarts := 0
+	totalContainers := len(pod.Spec.Containers)
+	readyContainers := 0
+	var lastRestart, lastSidecarRestart time.Time
+
+	reason := string(pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		reason = pod.Status.Reason
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Reason == corev1.PodReasonSchedulingGated {
+			reason = corev1.PodReasonSchedulingGated
+		}
+	}
+
+	initContainers := make(map[string]*corev1.Container, len(pod.Spec.InitContainers))
+	for i := range pod.Spec.InitContainers {
+		initContainers[pod.Spec.InitContainers[i].Name] = &pod.Spec.InitContainers[i]
+		if isSidecarContainer(&pod.Spec.InitContainers[i]) {
+			totalContainers++
+		}
+	}
+
+	initializing := false
+	for i := range pod.Status.InitContainerStatuses {
+		container := pod.Status.InitContainerStatuses[i]
+		restarts += int(container.RestartCount)
+		lastRestart = laterTermination(lastRestart, container)
+		sidecar := isSidecarContainer(initContainers[container.Name])
+		if sidecar {
+			sidecarRestarts += int(container.RestartCount)
+			lastSidecarRestart = laterTermination(lastSidecarRestart, container)
+		}
+
+		switch {
+		case container.State.Terminated != nil && container.State.Terminated.ExitCode == 0:
+			continue
+		case sidecar && container.Started != nil && *container.Started:
+			if container.Ready {
+				readyContainers++
+			}
+			continue
+		case container.State.Terminated != nil:
+			if container.State.Terminated.Reason != "" {
+				reason = "Init:" + container.State.Terminated.Reason
+			} else if container.State.Terminated.Signal != 0 {
+				reason = fmt.Sprintf("Init:Signal:%d", container.State.Terminated.Signal)
+			} else {
+				reason = fmt.Sprintf("Init:ExitCode:%d", container.State.Terminated.ExitCode)
+			}
+		case container.State.Waiting != nil && container.State.Waiting.Reason != "" && container.State.Waiting.Reason != "PodInitializing":
+			reason = "Init:" + container.State.Waiting.Reason
+		default:
+			reason = fmt.Sprintf("Init:%d/%d", i, len(pod.Spec.InitContainers))
+		}
+		initializing = true
+		break
+	}
+
+	if !initializing || podConditionTrue(pod, corev1.PodInitialized) {
+		restarts = sidecarRestarts
+		lastRestart = lastSidecarRestart
+		hasRunning := false
+		for i := len(pod.Status.ContainerStatuses) - 1; i >= 0; i-- {
+			container := pod.Status.ContainerStatuses[i]
+			restarts += int(container.RestartCount)
+			lastRestart = laterTermination(lastRestart, container)
+
+			switch {
+			case container.State.Waiting != nil && container.State.Waiting.Reason != "":
+				reason = container.State.Waiting.Reason
+			case container.State.Terminated != nil && container.State.Terminated.Reason != "":
+				reason = container.State.Terminated.Reason
+			case container.State.Terminated != nil && container.State.Terminated.Signal != 0:
+				reason = fmt.Sprintf("Signal:%d", container.State.Terminated.Signal)
+			case container.State.Terminated != nil:
+				reason = fmt.Sprintf("ExitCode:%d", container.State.Terminated.ExitCode)
+			case container.Ready && container.State.Running != nil:
+				hasRunning = true
+				readyContainers++
+			}
+		}
+
+		// A completed container next to running ones does not complete the pod
+		if reason == "Completed" && hasRunning {
+			if podConditionTrue(pod, corev1.PodReady) {
+				reason = "Running"
+			} else {
+				reason = "NotReady"
+			}
+		}
+	}
+
+	if pod.DeletionTimestamp != nil && pod.Status.Reason == nodeUnreachablePodReason {
+		reason = "Unknown"
+	} else if pod.DeletionTimestamp != nil && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		reason = "Terminating"
+	}
+	if reason == "" {
+		reason = "Unknown"
+	}
+
+	// Like kubectl, only show when the last restart was if there was one
+	if restarts == 0 {
+		lastRestart = time.Time{}
+	}
+
+	return podSummary{
+		ready:       fmt.Sprintf("%d/%d", readyContainers, totalContainers),
+		status:      reason,
+		restarts:    restarts,
+		lastRestart: lastRestart,
+	}
+}
+
+// isSidecarContainer reports whether an init container keeps running next to
+// the regular containers (restartPolicy: Always)
+func isSidecarContainer(container *corev1.Container) bool {
+	return container != nil && container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
+
+func laterTermination(last time.Time, status corev1.ContainerStatus) time.Time {
+	if terminated := status.LastTerminationState.Terminated; terminated != nil && last.Before(terminated.FinishedAt.Time) {
+		return terminated.FinishedAt.Time
+	}
+	return last
+}
+
+func podConditionTrue(pod *corev1.Pod, conditionType corev1.PodConditionType) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
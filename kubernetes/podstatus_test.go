@@ -0,0 +1,257 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var restartedAt = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// testPod returns a pod in phase whose containers are named after statuses
+func testPod(phase corev1.PodPhase, statuses ...corev1.ContainerStatus) *corev1.Pod {
+	pod := &corev1.Pod{Status: corev1.PodStatus{Phase: phase, ContainerStatuses: statuses}}
+	for _, status := range statuses {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: status.Name})
+	}
+	return pod
+}
+
+// withInit adds init containers, named after statuses, in front of pod's
+// containers; sidecars lists the ones that keep running
+func withInit(pod *corev1.Pod, sidecars map[string]bool, statuses ...corev1.ContainerStatus) *corev1.Pod {
+	always := corev1.ContainerRestartPolicyAlways
+	for _, status := range statuses {
+		container := corev1.Container{Name: status.Name}
+		if sidecars[status.Name] {
+			container.RestartPolicy = &always
+		}
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, container)
+	}
+	pod.Status.InitContainerStatuses = statuses
+	return pod
+}
+
+func withConditions(pod *corev1.Pod, conditions ...corev1.PodCondition) *corev1.Pod {
+	pod.Status.Conditions = conditions
+	return pod
+}
+
+func deleted(pod *corev1.Pod, reason string) *corev1.Pod {
+	pod.DeletionTimestamp = &metav1.Time{Time: restartedAt}
+	pod.Status.Reason = reason
+	return pod
+}
+
+func condition(conditionType corev1.PodConditionType, status corev1.ConditionStatus) corev1.PodCondition {
+	return corev1.PodCondition{Type: conditionType, Status: status}
+}
+
+func running(name string, ready bool) corev1.ContainerStatus {
+	started := true
+	return corev1.ContainerStatus{
+		Name:    name,
+		Ready:   ready,
+		Started: &started,
+		State:   corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+}
+
+func waiting(name, reason string) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:  name,
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}},
+	}
+}
+
+func terminated(name, reason string, exitCode, signal int32) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name: name,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			Reason:   reason,
+			ExitCode: exitCode,
+			Signal:   signal,
+		}},
+	}
+}
+
+// restarted records count restarts of status, the last one at restartedAt
+func restarted(status corev1.ContainerStatus, count int32) corev1.ContainerStatus {
+	status.RestartCount = count
+	status.LastTerminationState.Terminated = &corev1.ContainerStateTerminated{FinishedAt: metav1.Time{Time: restartedAt}}
+	return status
+}
+
+func TestSummarizePod(t *testing.T) {
+	sidecar := map[string]bool{"proxy": true}
+
+	tests := []struct {
+		name        string
+		pod         *corev1.Pod
+		ready       string
+		status      string
+		restarts    int
+		lastRestart time.Time
+	}{
+		{
+			name:   "running and ready",
+			pod:    testPod(corev1.PodRunning, running("app", true), running("log", true)),
+			ready:  "2/2",
+			status: "Running",
+		},
+		{
+			name:   "running but not ready",
+			pod:    testPod(corev1.PodRunning, running("app", false)),
+			ready:  "0/1",
+			status: "Running",
+		},
+		{
+			name:        "crash looping",
+			pod:         testPod(corev1.PodRunning, restarted(waiting("app", "CrashLoopBackOff"), 5)),
+			ready:       "0/1",
+			status:      "CrashLoopBackOff",
+			restarts:    5,
+			lastRestart: restartedAt,
+		},
+		{
+			name:   "completed",
+			pod:    testPod(corev1.PodSucceeded, terminated("job", "Completed", 0, 0)),
+			ready:  "0/1",
+			status: "Completed",
+		},
+		{
+			name: "completed container next to a ready one",
+			pod: withConditions(testPod(corev1.PodRunning, terminated("setup", "Completed", 0, 0), running("app", true)),
+				condition(corev1.PodReady, corev1.ConditionTrue)),
+			ready:  "1/2",
+			status: "Running",
+		},
+		{
+			name:   "completed container next to an unready pod",
+			pod:    testPod(corev1.PodRunning, terminated("setup", "Completed", 0, 0), running("app", true)),
+			ready:  "1/2",
+			status: "NotReady",
+		},
+		{
+			name:   "killed by a signal",
+			pod:    testPod(corev1.PodFailed, terminated("app", "", 137, 9)),
+			ready:  "0/1",
+			status: "Signal:9",
+		},
+		{
+			name:   "failed without a reason",
+			pod:    testPod(corev1.PodFailed, terminated("app", "", 2, 0)),
+			ready:  "0/1",
+			status: "ExitCode:2",
+		},
+		{
+			name:   "first init container starting",
+			pod:    withInit(testPod(corev1.PodPending, waiting("app", "PodInitializing")), nil, waiting("migrate", "PodInitializing"), waiting("seed", "PodInitializing")),
+			ready:  "0/1",
+			status: "Init:0/2",
+		},
+		{
+			name:   "second init container running",
+			pod:    withInit(testPod(corev1.PodPending, waiting("app", "PodInitializing")), nil, terminated("migrate", "Completed", 0, 0), running("seed", false)),
+			ready:  "0/1",
+			status: "Init:1/2",
+		},
+		{
+			name:        "init container crash looping",
+			pod:         withInit(testPod(corev1.PodPending, waiting("app", "PodInitializing")), nil, restarted(waiting("migrate", "CrashLoopBackOff"), 3)),
+			ready:       "0/1",
+			status:      "Init:CrashLoopBackOff",
+			restarts:    3,
+			lastRestart: restartedAt,
+		},
+		{
+			name:   "init container failed",
+			pod:    withInit(testPod(corev1.PodPending, waiting("app", "PodInitializing")), nil, terminated("migrate", "Error", 1, 0)),
+			ready:  "0/1",
+			status: "Init:Error",
+		},
+		{
+			name:   "init container failed without a reason",
+			pod:    withInit(testPod(corev1.PodPending, waiting("app", "PodInitializing")), nil, terminated("migrate", "", 3, 0)),
+			ready:  "0/1",
+			status: "Init:ExitCode:3",
+		},
+		{
+			name:   "init container killed by a signal",
+			pod:    withInit(testPod(corev1.PodPending, waiting("app", "PodInitializing")), nil, terminated("migrate", "", 137, 9)),
+			ready:  "0/1",
+			status: "Init:Signal:9",
+		},
+		{
+			name: "sidecar next to the app",
+			pod: withConditions(
+				withInit(testPod(corev1.PodRunning, restarted(running("app", true), 1)), sidecar, restarted(running("proxy", true), 2)),
+				condition(corev1.PodInitialized, corev1.ConditionTrue), condition(corev1.PodReady, corev1.ConditionTrue)),
+			ready:       "2/2",
+			status:      "Running",
+			restarts:    3,
+			lastRestart: restartedAt,
+		},
+		{
+			name:   "sidecar started before the next init container",
+			pod:    withInit(testPod(corev1.PodPending, waiting("app", "PodInitializing")), sidecar, running("proxy", false), waiting("migrate", "PodInitializing")),
+			ready:  "0/2",
+			status: "Init:1/2",
+		},
+		{
+			name:   "terminating",
+			pod:    deleted(testPod(corev1.PodRunning, running("app", true)), ""),
+			ready:  "1/1",
+			status: "Terminating",
+		},
+		{
+			name:   "terminating after completion",
+			pod:    deleted(testPod(corev1.PodSucceeded, terminated("job", "Completed", 0, 0)), ""),
+			ready:  "0/1",
+			status: "Completed",
+		},
+		{
+			name:   "node lost",
+			pod:    deleted(testPod(corev1.PodRunning, running("app", true)), nodeUnreachablePodReason),
+			ready:  "1/1",
+			status: "Unknown",
+		},
+		{
+			name: "evicted",
+			pod: func() *corev1.Pod {
+				pod := testPod(corev1.PodFailed)
+				pod.Spec.Containers = []corev1.Container{{Name: "app"}}
+				pod.Status.Reason = "Evicted"
+				return pod
+			}(),
+			ready:  "0/1",
+			status: "Evicted",
+		},
+		{
+			name: "scheduling gated",
+			pod: withConditions(testPod(corev1.PodPending),
+				corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonSchedulingGated}),
+			ready:  "0/0",
+			status: "SchedulingGated",
+		},
+		{
+			name:   "no phase",
+			pod:    testPod(""),
+			ready:  "0/0",
+			status: "Unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarizePod(tt.pod)
+			if got.ready != tt.ready || got.status != tt.status || got.restarts != tt.restarts || !got.lastRestart.Equal(tt.lastRestart) {
+				t.Errorf("summarizePod = %s %s %d (%v), want %s %s %d (%v)",
+					got.ready, got.status, got.restarts, got.lastRestart,
+					tt.ready, tt.status, tt.restarts, tt.lastRestart)
+			}
+		})
+	}
+}
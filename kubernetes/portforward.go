@@ -0,0 +1,299 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// ForwardPort is a port that can be forwarded to: a container port of a pod
+// or deployment, or a service port
+type ForwardPort struct {
+	Name     string
+	Port     int32
+	Protocol string
+	// target is the pod port a service port maps to
+	target intstr.IntOrString
+}
+
+// PortForward is a running forward from a local port to a pod. Its counters
+// and state are updated while it runs and are safe to read at any time.
+type PortForward struct {
+	Target     string
+	Namespace  string
+	Pod        string
+	LocalPort  int
+	RemotePort int
+	StartedAt  time.Time
+
+	bytesIn  atomic.Int64
+	bytesOut atomic.Int64
+	stop     chan struct{}
+	stopOnce sync.Once
+	mu       sync.Mutex
+	done     bool
+	err      error
+}
+
+// BytesIn returns how many bytes were received from the pod
+func (pf *PortForward) BytesIn() int64 { return pf.bytesIn.Load() }
+
+// BytesOut returns how many bytes were sent to the pod
+func (pf *PortForward) BytesOut() int64 { return pf.bytesOut.Load() }
+
+// Stop closes the local listener and every forwarded connection
+func (pf *PortForward) Stop() {
+	pf.stopOnce.Do(func() { close(pf.stop) })
+}
+
+// State reports whether the forward has ended, and the error it ended with
+// if it did not end by Stop
+func (pf *PortForward) State() (done bool, err error) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	return pf.done, pf.err
+}
+
+func (pf *PortForward) finish(err error) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	pf.done = true
+	pf.err = err
+}
+
+// ForwardablePorts lists the ports of a pod, service or deployment
+func (k *KubeClient) ForwardablePorts(ctx context.Context, resourceType, namespace, name string) ([]ForwardPort, error) {
+	namespace = namespaceOrDefault(namespace)
+	var ports []ForwardPort
+	switch resourceType {
+	case "pods":
+		pod, err := k.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod %s: %v", name, err)
+		}
+		ports = containerForwardPorts(pod.Spec.Containers)
+	case "services":
+		svc, err := k.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get service %s: %v", name, err)
+		}
+		for _, p := range svc.Spec.Ports {
+			target := p.TargetPort
+			if target.Type == intstr.Int && target.IntVal == 0 {
+				target = intstr.FromInt32(p.Port)
+			}
+			ports = append(ports, ForwardPort{Name: p.Name, Port: p.Port, Protocol: string(p.Protocol), target: target})
+		}
+	case "deployments":
+		deployment, err := k.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment %s: %v", name, err)
+		}
+		ports = containerForwardPorts(deployment.Spec.Template.Spec.Containers)
+	default:
+		return nil, fmt.Errorf("cannot forward ports of %s; only pods, services and deployments", resourceType)
+	}
+	return ports, nil
+}
+
+// StartPortForward forwards localPort to port of a pod, service or
+// deployment, like `kubectl port-forward`. Services and deployments forward
+// to one of their running pods. A localPort of 0 picks a free port. It
+// returns once the local port is listening.
+func (k *KubeClient) StartPortForward(ctx context.Context, resourceType, namespace, name string, port ForwardPort, localPort int) (*PortForward, error) {
+	namespace = namespaceOrDefault(namespace)
+	pod, err := k.forwardPod(ctx, resourceType, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	remotePort, err := resolvePodPort(pod, port)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(k.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create port-forward transport: %v", err)
+	}
+	url := k.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod.Name).
+		SubResource("portforward").
+		URL()
+
+	pf := &PortForward{
+		Target:     fmt.Sprintf("%s %s/%s", resourceType, namespace, name),
+		Namespace:  namespace,
+		Pod:        pod.Name,
+		RemotePort: remotePort,
+		StartedAt:  time.Now(),
+		stop:       make(chan struct{}),
+	}
+	dialer := &countingDialer{
+		Dialer: spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url),
+		pf:     pf,
+	}
+	ready := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"localhost"}, []string{fmt.Sprintf("%d:%d", localPort, remotePort)}, pf.stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forward to %s: %v", pod.Name, err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- forwarder.ForwardPorts() }()
+	select {
+	case <-ready:
+	case err := <-errCh:
+		if err == nil {
+			err = fmt.Errorf("forward stopped before it was ready")
+		}
+		return nil, fmt.Errorf("failed to forward to %s: %v", pod.Name, err)
+	case <-ctx.Done():
+		pf.Stop()
+		return nil, ctx.Err()
+	}
+
+	if forwarded, err := forwarder.GetPorts(); err == nil && len(forwarded) > 0 {
+		pf.LocalPort = int(forwarded[0].Local)
+	}
+	go func() { pf.finish(<-errCh) }()
+	return pf, nil
+}
+
+// forwardPod picks the pod a forward connects to: the pod itself, or the
+// first running pod selected by a service or deployment
+func (k *KubeClient) forwardPod(ctx context.Context, resourceType, namespace, name string) (*corev1.Pod, error) {
+	var selector labels.Selector
+	switch resourceType {
+	case "pods":
+		pod, err := k.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod %s: %v", name, err)
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			return nil, fmt.Errorf("pod %s is %s, not Running", name, pod.Status.Phase)
+		}
+		return pod, nil
+	case "services":
+		svc, err := k.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get service %s: %v", name, err)
+		}
+		if len(svc.Spec.Selector) == 0 {
+			return nil, fmt.Errorf("service %s has no selector to find pods with", name)
+		}
+		selector = labels.SelectorFromSet(svc.Spec.Selector)
+	case "deployments":
+		deployment, err := k.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment %s: %v", name, err)
+		}
+		selector, err = metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector of deployment %s: %v", name, err)
+		}
+	default:
+		return nil, fmt.Errorf("cannot forward ports of %s; only pods, services and deployments", resourceType)
+	}
+
+	list, err := k.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of %s/%s: %v", resourceType, name, err)
+	}
+	// Prefer the oldest running pod, the one least likely to go away
+	pods := list.Items
+	sort.Slice(pods, func(i, j int) bool { return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp) })
+	for i := range pods {
+		if pods[i].Status.Phase == corev1.PodRunning && pods[i].DeletionTimestamp == nil {
+			return &pods[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no running pods found for %s/%s", resourceType, name)
+}
+
+// resolvePodPort maps a forward port to the port number on the pod; named
+// service target ports are looked up among the pod's container ports
+func resolvePodPort(pod *corev1.Pod, port ForwardPort) (int, error) {
+	if port.target.Type == intstr.String {
+		for _, c := range pod.Spec.Containers {
+			for _, p := range c.Ports {
+				if p.Name == port.target.StrVal {
+					return int(p.ContainerPort), nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("pod %s has no port named %q", pod.Name, port.target.StrVal)
+	}
+	if port.target.IntVal != 0 {
+		return int(port.target.IntVal), nil
+	}
+	return int(port.Port), nil
+}
+
+func containerForwardPorts(containers []corev1.Container) []ForwardPort {
+	var ports []ForwardPort
+	for _, c := range containers {
+		for _, p := range c.Ports {
+			ports = append(ports, ForwardPort{Name: p.Name, Port: p.ContainerPort, Protocol: string(p.Protocol)})
+		}
+	}
+	return ports
+}
+
+// countingDialer counts the bytes of every stream a forward opens
+type countingDialer struct {
+	httpstream.Dialer
+	pf *PortForward
+}
+
+func (d *countingDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.Dialer.Dial(protocols...)
+	if err != nil {
+		return nil, protocol, err
+	}
+	return &countingConnection{Connection: conn, pf: d.pf}, protocol, nil
+}
+
+type countingConnection struct {
+	httpstream.Connection
+	pf *PortForward
+}
+
+func (c *countingConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	stream, err := c.Connection.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+	return &countingStream{Stream: stream, pf: c.pf}, nil
+}
+
+type countingStream struct {
+	httpstream.Stream
+	pf *PortForward
+}
+
+func (s *countingStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	s.pf.bytesIn.Add(int64(n))
+	return n, err
+}
+
+func (s *countingStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	s.pf.bytesOut.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,249 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Annotations the deployment controller and kubectl keep revisions with
+const (
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// RolloutRevision is one entry of a workload's rollout history
+type RolloutRevision struct {
+	Revision    int64
+	ChangeCause string
+	CreatedAt   time.Time
+	// Current marks the revision the pod template is at now
+	Current bool
+}
+
+// IsRolloutResource reports whether resourceType rolls out new pods, i.e. is
+// a deployment, statefulset or daemonset
+func IsRolloutResource(resourceType string) bool {
+	return rolloutResources[resourceType]
+}
+
+// SetRolloutPaused pauses or resumes a deployment's rollout like `kubectl
+// rollout pause/resume` by setting spec.paused
+func (k *KubeClient) SetRolloutPaused(ctx context.Context, resourceType, namespace, name string, paused bool) error {
+	if resourceType != "deployments" {
+		return fmt.Errorf("cannot pause %s; only deployments can be paused", resourceType)
+	}
+	verb := "pause"
+	if !paused {
+		verb = "resume"
+	}
+	patch := map[string]interface{}{"spec": map[string]interface{}{"paused": paused}}
+	if err := k.mergePatch(ctx, resourceType, namespace, name, patch); err != nil {
+		return fmt.Errorf("failed to %s %s/%s: %v", verb, resourceType, name, err)
+	}
+	return nil
+}
+
+// RolloutHistory lists the revisions of a workload, oldest first, like
+// `kubectl rollout history`. Deployment revisions come from their
+// ReplicaSets, statefulset and daemonset revisions from ControllerRevisions.
+func (k *KubeClient) RolloutHistory(ctx context.Context, resourceType, namespace, name string) ([]RolloutRevision, error) {
+	namespace = namespaceOrDefault(namespace)
+	var revisions []RolloutRevision
+	switch resourceType {
+	case "deployments":
+		_, replicaSets, err := k.deploymentReplicaSets(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, rs := range replicaSets {
+			revision, err := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+			if err != nil {
+				continue
+			}
+			revisions = append(revisions, RolloutRevision{
+				Revision:    revision,
+				ChangeCause: rs.Annotations[changeCauseAnnotation],
+				CreatedAt:   rs.CreationTimestamp.Time,
+			})
+		}
+	case "statefulsets", "daemonsets":
+		history, err := k.controllerRevisions(ctx, resourceType, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, cr := range history {
+			revisions = append(revisions, RolloutRevision{
+				Revision:    cr.Revision,
+				ChangeCause: cr.Annotations[changeCauseAnnotation],
+				CreatedAt:   cr.CreationTimestamp.Time,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("%s have no rollout history; only deployments, statefulsets and daemonsets do", resourceType)
+	}
+
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision < revisions[j].Revision })
+	// Rolling back re-issues the old template under a new revision number, so
+	// the highest revision is always the one rolled out
+	if len(revisions) > 0 {
+		revisions[len(revisions)-1].Current = true
+	}
+	return revisions, nil
+}
+
+// UndoRollout rolls a workload back to an earlier revision like `kubectl
+// rollout undo --to-revision`
+func (k *KubeClient) UndoRollout(ctx context.Context, resourceType, namespace, name string, revision int64) error {
+	if err := k.ensureWritable(); err != nil {
+		return err
+	}
+	namespace = namespaceOrDefault(namespace)
+	var err error
+	switch resourceType {
+	case "deployments":
+		err = k.undoDeployment(ctx, namespace, name, revision)
+	case "statefulsets", "daemonsets":
+		err = k.undoControllerRevision(ctx, resourceType, namespace, name, revision)
+	default:
+		return fmt.Errorf("cannot roll back %s; only deployments, statefulsets and daemonsets can be rolled back", resourceType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to roll back %s/%s to revision %d: %v", resourceType, name, revision, err)
+	}
+	return nil
+}
+
+// undoDeployment copies the pod template of the revision's ReplicaSet back
+// into the deployment
+func (k *KubeClient) undoDeployment(ctx context.Context, namespace, name string, revision int64) error {
+	deployment, replicaSets, err := k.deploymentReplicaSets(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	if deployment.Spec.Paused {
+		return fmt.Errorf("the deployment is paused; resume it first")
+	}
+
+	var template *corev1.PodTemplateSpec
+	var changeCause string
+	for _, rs := range replicaSets {
+		if rs.Annotations[revisionAnnotation] == strconv.FormatInt(revision, 10) {
+			template = rs.Spec.Template.DeepCopy()
+			changeCause = rs.Annotations[changeCauseAnnotation]
+			break
+		}
+	}
+	if template == nil {
+		return fmt.Errorf("revision not found")
+	}
+	// The hash label is added by the deployment controller per ReplicaSet
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	ops := []map[string]interface{}{{"op": "replace", "path": "/spec/template", "value": template}}
+	if changeCause != "" {
+		annotations := make(map[string]string, len(deployment.Annotations)+1)
+		for key, value := range deployment.Annotations {
+			annotations[key] = value
+		}
+		annotations[changeCauseAnnotation] = changeCause
+		ops = append(ops, map[string]interface{}{"op": "add", "path": "/metadata/annotations", "value": annotations})
+	}
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return fmt.Errorf("failed to encode patch: %v", err)
+	}
+	_, err = k.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.JSONPatchType, data, metav1.PatchOptions{FieldManager: fieldManager})
+	return err
+}
+
+// undoControllerRevision re-applies the patch a ControllerRevision stores,
+// which holds the pod template of that revision
+func (k *KubeClient) undoControllerRevision(ctx context.Context, resourceType, namespace, name string, revision int64) error {
+	history, err := k.controllerRevisions(ctx, resourceType, namespace, name)
+	if err != nil {
+		return err
+	}
+	for _, cr := range history {
+		if cr.Revision != revision {
+			continue
+		}
+		client, err := k.resourceClient(ctx, resourceType, namespace)
+		if err != nil {
+			return err
+		}
+		_, err = client.Patch(ctx, name, types.StrategicMergePatchType, cr.Data.Raw, metav1.PatchOptions{FieldManager: fieldManager})
+		return err
+	}
+	return fmt.Errorf("revision not found")
+}
+
+// deploymentReplicaSets returns a deployment and the ReplicaSets it controls
+func (k *KubeClient) deploymentReplicaSets(ctx context.Context, namespace, name string) (*appsv1.Deployment, []appsv1.ReplicaSet, error) {
+	deployment, err := k.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get deployment %s: %v", name, err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid selector of deployment %s: %v", name, err)
+	}
+	list, err := k.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list replicasets of %s: %v", name, err)
+	}
+	var owned []appsv1.ReplicaSet
+	for _, rs := range list.Items {
+		if metav1.IsControlledBy(&rs, deployment) {
+			owned = append(owned, rs)
+		}
+	}
+	return deployment, owned, nil
+}
+
+// controllerRevisions returns the ControllerRevisions of a statefulset or
+// daemonset
+func (k *KubeClient) controllerRevisions(ctx context.Context, resourceType, namespace, name string) ([]appsv1.ControllerRevision, error) {
+	var owner metav1.Object
+	var labelSelector *metav1.LabelSelector
+	switch resourceType {
+	case "statefulsets":
+		sts, err := k.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset %s: %v", name, err)
+		}
+		owner, labelSelector = sts, sts.Spec.Selector
+	case "daemonsets":
+		ds, err := k.clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daemonset %s: %v", name, err)
+		}
+		owner, labelSelector = ds, ds.Spec.Selector
+	default:
+		return nil, fmt.Errorf("%s have no controller revisions", resourceType)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of %s/%s: %v", resourceType, name, err)
+	}
+	list, err := k.clientset.AppsV1().ControllerRevisions(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions of %s/%s: %v", resourceType, name, err)
+	}
+	var owned []appsv1.ControllerRevision
+	for _, cr := range list.Items {
+		if metav1.IsControlledBy(&cr, owner) {
+			owned = append(owned, cr)
+		}
+	}
+	return owned, nil
+}
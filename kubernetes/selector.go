@@ -0,0 +1,58 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Selector narrows a listing to the objects matching a label selector
+// ("app=web,tier!=cache") and a field selector ("status.phase=Running").
+// Both are evaluated by the API server.
+type Selector struct {
+	Label string
+	Field string
+}
+
+// ParseSelector validates a label and a field selector; either may be empty
+func ParseSelector(label, field string) (Selector, error) {
+	sel := Selector{Label: strings.TrimSpace(label), Field: strings.TrimSpace(field)}
+	if _, err := labels.Parse(sel.Label); err != nil {
+		return Selector{}, fmt.Errorf("invalid label selector %q: %v", sel.Label, err)
+	}
+	if _, err := fields.ParseSelector(sel.Field); err != nil {
+		return Selector{}, fmt.Errorf("invalid field selector %q: %v", sel.Field, err)
+	}
+	return sel, nil
+}
+
+// WithLabel returns the selector with its label selector replaced, keeping
+// the field selector
+func (s Selector) WithLabel(label string) Selector {
+	s.Label = label
+	return s
+}
+
+func (s Selector) IsEmpty() bool {
+	return s.Label == "" && s.Field == ""
+}
+
+// String renders the selector for display, e.g. "-l app=web --field-selector status.phase=Running"
+func (s Selector) String() string {
+	var parts []string
+	if s.Label != "" {
+		parts = append(parts, "-l "+s.Label)
+	}
+	if s.Field != "" {
+		parts = append(parts, "--field-selector "+s.Field)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ListOptions returns the list options that apply the selector on the server
+func (s Selector) ListOptions() metav1.ListOptions {
+	return metav1.ListOptions{LabelSelector: s.Label, FieldSelector: s.Field}
+}
@@ -0,0 +1,113 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		name         string
+		label, field string
+		want         Selector
+		wantErr      string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "label only",
+			label: "app=web,tier!=cache",
+			want:  Selector{Label: "app=web,tier!=cache"},
+		},
+		{
+			name:  "field only",
+			field: "status.phase=Running",
+			want:  Selector{Field: "status.phase=Running"},
+		},
+		{
+			name:  "label and field",
+			label: "app in (web, api)",
+			field: "spec.nodeName=node-1,metadata.namespace!=kube-system",
+			want:  Selector{Label: "app in (web, api)", Field: "spec.nodeName=node-1,metadata.namespace!=kube-system"},
+		},
+		{
+			name:  "surrounding whitespace",
+			label: "  app=web ",
+			field: "\tstatus.phase=Running\n",
+			want:  Selector{Label: "app=web", Field: "status.phase=Running"},
+		},
+		{
+			name:    "invalid label",
+			label:   "app=(web",
+			field:   "status.phase=Running",
+			wantErr: "invalid label selector",
+		},
+		{
+			name:    "field given as label value",
+			label:   "status.phase=Running=x",
+			wantErr: "invalid label selector",
+		},
+		{
+			name:    "invalid field",
+			label:   "app=web",
+			field:   "status.phase",
+			wantErr: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSelector(tt.label, tt.field)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSelector error = %v, want %q", err, tt.wantErr)
+				}
+				if !got.IsEmpty() {
+					t.Errorf("ParseSelector = %+v on error, want an empty selector", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSelector: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSelector = %+v, want %+v", got, tt.want)
+			}
+			opts := got.ListOptions()
+			if opts.LabelSelector != tt.want.Label || opts.FieldSelector != tt.want.Field {
+				t.Errorf("ListOptions = %q/%q, want %q/%q", opts.LabelSelector, opts.FieldSelector, tt.want.Label, tt.want.Field)
+			}
+		})
+	}
+}
+
+func TestSelectorString(t *testing.T) {
+	tests := []struct {
+		sel  Selector
+		want string
+	}{
+		{Selector{}, ""},
+		{Selector{Label: "app=web"}, "-l app=web"},
+		{Selector{Field: "status.phase=Running"}, "--field-selector status.phase=Running"},
+		{Selector{Label: "app=web", Field: "status.phase=Running"}, "-l app=web --field-selector status.phase=Running"},
+	}
+	for _, tt := range tests {
+		if got := tt.sel.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.sel, got, tt.want)
+		}
+	}
+}
+
+// Picking a label in the labels panel once dropped the active field selector
+func TestSelectorWithLabelKeepsField(t *testing.T) {
+	sel := Selector{Label: "app=web", Field: "status.phase=Running"}
+	got := sel.WithLabel("tier=cache")
+	want := Selector{Label: "tier=cache", Field: "status.phase=Running"}
+	if got != want {
+		t.Errorf("WithLabel = %+v, want %+v", got, want)
+	}
+	if sel.Label != "app=web" {
+		t.Errorf("WithLabel changed the original selector to %+v", sel)
+	}
+}
@@ -0,0 +1,199 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// tableAcceptHeader asks the API server for its Table rendering of a list,
+// the same representation `kubectl get` prints. Plain JSON is accepted as a
+// fallback for servers that cannot render tables.
+const tableAcceptHeader = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json"
+
+// errNotTable is returned by getTable when the server answered with
+// something other than a Table
+var errNotTable = errors.New("server did not return a Table")
+
+// isTableUnsupported reports whether a Table request failed because the
+// server cannot render Tables for the resource, rather than failing outright
+func isTableUnsupported(err error) bool {
+	return errors.Is(err, errNotTable) || apierrors.IsNotAcceptable(err) || apierrors.IsUnsupportedMediaType(err)
+}
+
+// tablePath builds the REST path of a resource collection or, with a name, of
+// a single object. An empty namespace addresses all namespaces.
+func tablePath(gvr schema.GroupVersionResource, namespace, name string) string {
+	segments := []string{"/apis", gvr.Group, gvr.Version}
+	if gvr.Group == "" {
+		segments = []string{"/api", gvr.Version}
+	}
+	if namespace != "" {
+		segments = append(segments, "namespaces", namespace)
+	}
+	segments = append(segments, gvr.Resource)
+	if name != "" {
+		segments = append(segments, name)
+	}
+	return strings.Join(segments, "/")
+}
+
+// getTable fetches the server-side Table for a collection, limited to the
+// objects matching sel, or for a single object. Rows carry their object's
+// metadata so names and namespaces are reliable.
+func (k *KubeClient) getTable(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string, sel Selector) (*metav1.Table, error) {
+	req := k.disco.RESTClient().Get().
+		AbsPath(tablePath(gvr, namespace, name)).
+		Param("includeObject", string(metav1.IncludeMetadata)).
+		SetHeader("Accept", tableAcceptHeader)
+	if sel.Label != "" {
+		req = req.Param("labelSelector", sel.Label)
+	}
+	if sel.Field != "" {
+		req = req.Param("fieldSelector", sel.Field)
+	}
+	raw, err := req.Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	var table metav1.Table
+	if err := json.Unmarshal(raw, &table); err != nil {
+		return nil, fmt.Errorf("failed to decode table: %w: %v", errNotTable, err)
+	}
+	if table.Kind != "Table" {
+		return nil, fmt.Errorf("%w: got %s", errNotTable, table.Kind)
+	}
+	return &table, nil
+}
+
+// tableColumns converts the server's column definitions to table columns.
+// Only priority 0 columns are kept (those `kubectl get` shows without -o
+// wide), and the name column is dropped since the table always shows it.
+func tableColumns(table *metav1.Table) ([]Column, []int) {
+	var columns []Column
+	var indexes []int
+	for i, def := range table.ColumnDefinitions {
+		if def.Priority != 0 || def.Format == "name" {
+			continue
+		}
+		column := Column{Name: strings.ToUpper(def.Name), MinWidth: len(def.Name), MaxWidth: 40}
+		switch def.Type {
+		case "integer", "number", "boolean":
+			column.MaxWidth = 12
+		case "date":
+			column.MaxWidth = 16
+		}
+		if column.MaxWidth < column.MinWidth {
+			column.MaxWidth = column.MinWidth
+		}
+		columns = append(columns, column)
+		indexes = append(indexes, i)
+	}
+	return columns, indexes
+}
+
+// formatTableCell renders a cell value the way kubectl prints it
+func formatTableCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		if v == "" {
+			return "<none>"
+		}
+		return v
+	case float64:
+		if v == math.Trunc(v) {
+			return fmt.Sprintf("%d", int64(v))
+		}
+		return fmt.Sprintf("%g", v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, formatTableCell(item))
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+// tableResourceInfos converts Table rows to ResourceInfos whose Fields hold
+// the server-provided columns, including CRD additionalPrinterColumns.
+func tableResourceInfos(table *metav1.Table, resource string) []ResourceInfo {
+	columns, indexes := tableColumns(table)
+
+	results := make([]ResourceInfo, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		info := ResourceInfo{
+			Type:    resource,
+			Columns: columns,
+			Fields:  make(map[string]string, len(columns)),
+		}
+
+		var meta metav1.PartialObjectMetadata
+		if len(row.Object.Raw) > 0 && json.Unmarshal(row.Object.Raw, &meta) == nil {
+			info.Name = meta.Name
+			info.Namespace = meta.Namespace
+			info.CreatedAt = meta.CreationTimestamp.Time
+			info.Labels = meta.Labels
+			info.Annotations = meta.Annotations
+		}
+		if info.Name == "" && len(row.Cells) > 0 {
+			info.Name = formatTableCell(row.Cells[0])
+		}
+
+		for ci, idx := range indexes {
+			if idx >= len(row.Cells) {
+				continue
+			}
+			info.Fields[columns[ci].Name] = formatTableCell(row.Cells[idx])
+			if table.ColumnDefinitions[idx].Type != "date" {
+				continue
+			}
+			// CRD date columns arrive as timestamps; kubectl shows them as ages
+			if s, ok := row.Cells[idx].(string); ok {
+				if t, err := time.Parse(time.RFC3339, s); err == nil {
+					if info.Timestamps == nil {
+						info.Timestamps = make(map[string]time.Time)
+					}
+					info.Timestamps[columns[ci].Name] = t
+				}
+			}
+		}
+		info.Ready = info.Fields["READY"]
+		info.Status = info.Fields["STATUS"]
+		info.Restarts = info.Fields["RESTARTS"]
+		info.Age = info.Fields["AGE"]
+
+		results = append(results, info)
+	}
+	return results
+}
+
+// listTableResources lists a resource through the server-side Table API and
+// returns the rows along with the list's resourceVersion.
+func (k *KubeClient) listTableResources(ctx context.Context, resource, namespace string, sel Selector) ([]ResourceInfo, string, error) {
+	ns, isAll := normalizeNamespaceForList(namespace)
+	gvr, namespaced, err := k.resolveResourceGVR(ctx, resource)
+	if err != nil {
+		return nil, "", err
+	}
+	if !namespaced || isAll {
+		ns = ""
+	}
+
+	table, err := k.getTable(ctx, gvr, ns, "", sel)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list %s: %w", resource, err)
+	}
+	return tableResourceInfos(table, resource), table.ResourceVersion, nil
+}
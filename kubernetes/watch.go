@@ -0,0 +1,300 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// WatchEventType describes what happened to a watched resource
+type WatchEventType string
+
+const (
+	WatchAdded    WatchEventType = "ADDED"
+	WatchModified WatchEventType = "MODIFIED"
+	WatchDeleted  WatchEventType = "DELETED"
+	// WatchResync replaces the whole list, sent after the watch had to relist
+	// because its resourceVersion expired.
+	WatchResync WatchEventType = "RESYNC"
+	// WatchFailed reports an error; the watch keeps retrying after it.
+	WatchFailed WatchEventType = "ERROR"
+)
+
+// tableRelistInterval is the shortest time between two relists of a watched
+// type whose rows come from the server-side Table. Fetching a Table row per
+// event would cost more on a busy namespace than the polling the watch
+// replaces.
+const tableRelistInterval = 2 * time.Second
+
+// WatchEvent is a single change delivered by WatchResources
+type WatchEvent struct {
+	Type      WatchEventType
+	Resource  ResourceInfo
+	Resources []ResourceInfo
+	Err       error
+}
+
+// resourceInfoFromObject converts a watched object to a ResourceInfo using the
+// same per-kind conversion as the list functions.
+func resourceInfoFromObject(resourceType string, obj *unstructured.Unstructured) ResourceInfo {
+	switch resourceType {
+	case "pods":
+		var pod corev1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod); err == nil {
+			return podResourceInfo(&pod)
+		}
+	case "services":
+		var service corev1.Service
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &service); err == nil {
+			return serviceResourceInfo(&service)
+		}
+	case "deployments":
+		var deployment appsv1.Deployment
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deployment); err == nil {
+			return deploymentResourceInfo(&deployment)
+		}
+	}
+	return genericResourceInfo(obj, resourceType)
+}
+
+// typedWatchConversions lists the resource types resourceInfoFromObject
+// converts itself; every other type is rendered through server-side tables.
+var typedWatchConversions = map[string]bool{
+	"pods":        true,
+	"services":    true,
+	"deployments": true,
+}
+
+// watchScope is what a watch lists and watches: the dynamic resource
+// interface plus what is needed to fetch the same objects as Table rows.
+type watchScope struct {
+	target         dynamic.ResourceInterface
+	gvr            schema.GroupVersionResource
+	tableNamespace string
+	resourceType   string
+	selector       Selector
+}
+
+// watchTarget resolves the scope of a type/namespace pair
+func (k *KubeClient) watchTarget(ctx context.Context, resourceType, namespace string, sel Selector) (watchScope, error) {
+	ns, isAll := normalizeNamespaceForList(namespace)
+	gvr, namespaced, err := k.resolveResourceGVR(ctx, resourceType)
+	if err != nil {
+		return watchScope{}, err
+	}
+	scope := watchScope{gvr: gvr, resourceType: resourceType, selector: sel}
+	if namespaced && !isAll {
+		scope.target = k.dynamic.Resource(gvr).Namespace(ns)
+		scope.tableNamespace = ns
+	} else {
+		scope.target = k.dynamic.Resource(gvr)
+	}
+	return scope, nil
+}
+
+// listScope lists the resources and returns them with the list's resourceVersion.
+// Types without a typed conversion use the server-side Table so the rows
+// carry the same columns as a regular listing, unless the server cannot
+// render one.
+func (k *KubeClient) listScope(ctx context.Context, scope watchScope) ([]ResourceInfo, string, error) {
+	if !typedWatchConversions[scope.resourceType] {
+		table, err := k.getTable(ctx, scope.gvr, scope.tableNamespace, "", scope.selector)
+		if err == nil {
+			return tableResourceInfos(table, scope.resourceType), table.ResourceVersion, nil
+		}
+		if !isTableUnsupported(err) {
+			return nil, "", fmt.Errorf("failed to list %s: %v", scope.resourceType, err)
+		}
+	}
+	return listForWatch(ctx, scope.target, scope.resourceType, scope.selector)
+}
+
+// listForWatch lists the resources and returns them with the list's resourceVersion
+func listForWatch(ctx context.Context, target dynamic.ResourceInterface, resourceType string, sel Selector) ([]ResourceInfo, string, error) {
+	ulist, err := target.List(ctx, sel.ListOptions())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list %s: %v", resourceType, err)
+	}
+	results := make([]ResourceInfo, 0, len(ulist.Items))
+	for i := range ulist.Items {
+		results = append(results, resourceInfoFromObject(resourceType, &ulist.Items[i]))
+	}
+	return results, ulist.GetResourceVersion(), nil
+}
+
+// WatchResources lists the resources once and then streams changes starting
+// from the list's resourceVersion, so no event between the list and the watch
+// is lost. Dropped connections resume from the last seen resourceVersion; if
+// that version has expired the resources are relisted and a WatchResync event
+// is sent. Types shown through server-side Tables are relisted at most once
+// per tableRelistInterval when they change, also as a WatchResync. Only
+// objects matching sel are listed and watched. The channel is closed once
+// ctx is cancelled.
+func (k *KubeClient) WatchResources(ctx context.Context, resourceType, namespace string, sel Selector) ([]ResourceInfo, <-chan WatchEvent, error) {
+	scope, err := k.watchTarget(ctx, resourceType, namespace, sel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	initial, resourceVersion, err := k.listScope(ctx, scope)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lw := &cache.ListWatch{
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = sel.Label
+			options.FieldSelector = sel.Field
+			return scope.target.Watch(ctx, options)
+		},
+	}
+	// Rows of generic types come from the server-side Table, which cannot
+	// be built from the watched object
+	var relist func() ([]ResourceInfo, error)
+	if !typedWatchConversions[resourceType] {
+		relist = func() ([]ResourceInfo, error) {
+			items, _, err := k.listScope(ctx, scope)
+			return items, err
+		}
+	}
+
+	events := make(chan WatchEvent)
+	send := func(ev WatchEvent) bool {
+		select {
+		case events <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	backoff := func() bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(time.Second):
+			return true
+		}
+	}
+
+	go func() {
+		defer close(events)
+		for ctx.Err() == nil {
+			watcher, err := watchtools.NewRetryWatcher(resourceVersion, lw)
+			if err != nil {
+				send(WatchEvent{Type: WatchFailed, Err: err})
+				return
+			}
+
+			needsRelist := consumeWatch(ctx, watcher, resourceType, relist, &resourceVersion, send)
+			watcher.Stop()
+			if ctx.Err() != nil {
+				return
+			}
+			if !needsRelist {
+				// The retry watcher gave up on a non-retryable error; back off
+				// before resuming from the last resourceVersion.
+				if !backoff() {
+					return
+				}
+				continue
+			}
+
+			items, rv, err := k.listScope(ctx, scope)
+			if err != nil {
+				if !send(WatchEvent{Type: WatchFailed, Err: err}) || !backoff() {
+					return
+				}
+				continue
+			}
+			resourceVersion = rv
+			if !send(WatchEvent{Type: WatchResync, Resources: items}) {
+				return
+			}
+		}
+	}()
+
+	return initial, events, nil
+}
+
+// consumeWatch forwards events from watcher until it stops or ctx is done,
+// tracking the last seen resourceVersion. With relist set, added and modified
+// objects are not converted; instead the changes of every
+// tableRelistInterval are picked up by a single relist, sent as a resync.
+// It reports whether a relist is needed, because the resourceVersion expired
+// or changes were still waiting for one.
+func consumeWatch(ctx context.Context, watcher *watchtools.RetryWatcher, resourceType string, relist func() ([]ResourceInfo, error), resourceVersion *string, send func(WatchEvent) bool) bool {
+	var relistTimer <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-relistTimer:
+			relistTimer = nil
+			items, err := relist()
+			if err != nil {
+				if !send(WatchEvent{Type: WatchFailed, Err: err}) {
+					return false
+				}
+				continue
+			}
+			if !send(WatchEvent{Type: WatchResync, Resources: items}) {
+				return false
+			}
+		case ev, ok := <-watcher.ResultChan():
+			if !ok {
+				return relistTimer != nil
+			}
+
+			if ev.Type == watch.Error {
+				err := apierrors.FromObject(ev.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					return true
+				}
+				if !send(WatchEvent{Type: WatchFailed, Err: err}) {
+					return false
+				}
+				continue
+			}
+
+			obj, isUnstructured := ev.Object.(*unstructured.Unstructured)
+			if !isUnstructured {
+				continue
+			}
+			*resourceVersion = obj.GetResourceVersion()
+
+			var eventType WatchEventType
+			switch ev.Type {
+			case watch.Added:
+				eventType = WatchAdded
+			case watch.Modified:
+				eventType = WatchModified
+			case watch.Deleted:
+				eventType = WatchDeleted
+			default:
+				continue
+			}
+			if relist != nil && eventType != WatchDeleted {
+				if relistTimer == nil {
+					relistTimer = time.After(tableRelistInterval)
+				}
+				continue
+			}
+			if !send(WatchEvent{Type: eventType, Resource: resourceInfoFromObject(resourceType, obj)}) {
+				return false
+			}
+		}
+	}
+}
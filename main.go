@@ -1,15 +1,21 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"l8zykube/components"
 	"l8zykube/kubernetes"
+	"l8zykube/templates"
 	widgets "l8zykube/widgets"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,53 +30,602 @@ type MainModel struct {
 	modal              *components.Modal
 	logsModal          *components.LogsModal
 	describeModal      *components.DescribeModal
+	menuModal          *components.MenuModal
+	promptModal        *components.PromptModal
+	resultsModal       *components.ResultsModal
+	forwardsPanel      *components.PortForwardsPanel
+	diffModal          *components.DiffModal
+	eventsPanel        *components.EventsPanel
 	showModal          bool
+	showMenuModal      bool
+	showPromptModal    bool
+	showResultsModal   bool
+	showForwardsPanel  bool
+	showDiffModal      bool
+	showEventsPanel    bool
+	menuAction         menuAction
+	promptAction       promptAction
 	showLogsModal      bool
 	showDescribeModal  bool
 	watching           bool
 	watchResource      string
 	watchNamespace     string
-	runningKubectlEdit bool
+	watchSession       int
+	watchCancel        context.CancelFunc
+	resourceEditActive bool
+	editObject         *kubernetes.EditableObject
+	editDraft          []byte
+	clientOptions      kubernetes.ClientOptions
+	tableResource      string
+	tableNamespace     string
+	tableSelector      kubernetes.Selector
+	selectorDraft      string
+	deleteTarget       *kubernetes.ResourceInfo
+	deleteOptions      kubernetes.DeleteOptions
+	pending            *pendingActions
+	requests           *requestTracker
+	logTarget          kubernetes.ResourceInfo
+	logOptions         kubernetes.LogOptions
+	logContainers      []kubernetes.ContainerInfo
+	logSession         int
+	logCancel          context.CancelFunc
+	exportContent      string
+	bulkTargets        []kubernetes.ResourceInfo
+	scaleTarget        kubernetes.ResourceInfo
+	rolloutTarget      kubernetes.ResourceInfo
+	shellTarget        kubernetes.ResourceInfo
+	forwards           []*kubernetes.PortForward
+	forwardTarget      kubernetes.ResourceInfo
+	forwardPorts       []kubernetes.ForwardPort
+	forwardPort        kubernetes.ForwardPort
+	jumpTarget         kubernetes.ResourceInfo
 }
 
-type WatchTick struct{}
+// menuAction records what the open MenuModal was opened for
+type menuAction int
+
+const (
+	menuNone menuAction = iota
+	menuCreateSource
+	menuLogContainer
+	menuBulkAction
+	menuRollout
+	menuRolloutRevision
+	menuExecContainer
+	menuForwardPort
+)
+
+// promptAction records what the open PromptModal was opened for
+type promptAction int
+
+const (
+	promptNone promptAction = iota
+	promptCreateFromFile
+	promptLogSinceTime
+	promptSaveExport
+	promptLabelSelector
+	promptFieldSelector
+	promptBulkLabel
+	promptBulkAnnotate
+	promptBulkScale
+	promptScale
+	promptForwardPorts
+)
+
+var deletePropagationCycle = []metav1.DeletionPropagation{
+	"",
+	metav1.DeletePropagationBackground,
+	metav1.DeletePropagationForeground,
+	metav1.DeletePropagationOrphan,
+}
+
+const createManifestHeader = `# Edit the manifest below, then save and close the editor to create it.
+# Lines starting with '#' are ignored and an empty file aborts the creation.
+# Several objects can be separated with "---".
+`
+
+// isBlankManifest reports whether a manifest contains nothing but comments and whitespace
+func isBlankManifest(manifest string) bool {
+	for _, line := range strings.Split(manifest, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") && trimmed != "---" {
+			return false
+		}
+	}
+	return true
+}
+
+func createSourceMenuItems() []components.MenuItem {
+	items := make([]components.MenuItem, 0, len(templates.Catalog)+2)
+	for _, name := range templates.Catalog {
+		items = append(items, components.MenuItem{
+			Label:       fmt.Sprintf("Template: %s", name),
+			Description: fmt.Sprintf("Start from the built-in %s manifest", name),
+			Value:       "template:" + name,
+		})
+	}
+	items = append(items,
+		components.MenuItem{Label: "From clipboard", Description: "Start from the manifest in the clipboard", Value: "clipboard"},
+		components.MenuItem{Label: "From file...", Description: "Start from a local manifest file", Value: "file"},
+	)
+	return items
+}
+
+// selectedNamespaceForCreate returns the namespace new objects default to
+func (m MainModel) selectedNamespaceForCreate() string {
+	if namespaceWidget, ok := m.widgets[0].(*widgets.NameSpaceWidget); ok {
+		ns := strings.TrimSpace(namespaceWidget.GetSelectedNameSpace())
+		if ns != "" && !strings.EqualFold(ns, "all") {
+			return ns
+		}
+	}
+	if m.kubeClient != nil {
+		return m.kubeClient.DefaultNamespace()
+	}
+	return "default"
+}
+
+// readOnly reports whether mutating actions are disabled. The connected
+// client decides, falling back to the flags when there is no client.
+func (m MainModel) readOnly() bool {
+	if m.kubeClient != nil {
+		return m.kubeClient.ReadOnly()
+	}
+	return m.clientOptions.ReadOnly
+}
+
+// loadEvents lists the events of namespace for the events panel
+func (m MainModel) loadEvents(namespace string) tea.Cmd {
+	ctx, id := m.requests.Start(requestEvents)
+	return tea.Batch(
+		m.widgets[2].SetLoading(fmt.Sprintf("Loading events in %s...", namespaceDisplayFromQuery(namespace))),
+		loadEventsCmd(ctx, m.kubeClient, id, namespace),
+	)
+}
+
+// refreshTable re-lists the resources currently shown in the table
+func (m MainModel) refreshTable() tea.Cmd {
+	if m.kubeClient == nil || m.tableResource == "" {
+		return nil
+	}
+	return m.loadResources(m.tableResource, m.tableNamespace, true)
+}
+
+// startBulkAction runs the bulk action chosen from the menu on m.bulkTargets,
+// after a confirmation or a prompt for its input
+func (m MainModel) startBulkAction(action string) (MainModel, tea.Cmd) {
+	targets, client := m.bulkTargets, m.kubeClient
+	switch action {
+	case "delete", "restart":
+		m.bulkTargets = nil
+		verb, run := "Delete", bulkDeleteCmd
+		if action == "restart" {
+			verb, run = "Restart", bulkRestartCmd
+		}
+		m.modal.ShowConfirm(verb+" Resources", bulkConfirmMessage(verb, targets), func() {
+			m.pending.Add(run(client, targets))
+		}, nil)
+		m.modal.Type = components.ModalWarning
+		m.showModal = true
+		return m, nil
+	case "label":
+		m.promptModal.Show("Label Resources", "key=value sets a label, key- removes it; separate changes with commas", "app=web", "")
+		m.promptAction = promptBulkLabel
+	case "annotate":
+		m.promptModal.Show("Annotate Resources", "key=value sets an annotation, key- removes it; separate changes with commas", "team=platform", "")
+		m.promptAction = promptBulkAnnotate
+	case "scale":
+		m.promptModal.Show("Scale Resources", fmt.Sprintf("Replica count for %d resources", len(targets)), "1", "")
+		m.promptAction = promptBulkScale
+	default:
+		return m, nil
+	}
+	m.promptModal.SetDimensions(m.width, m.height)
+	m.showPromptModal = true
+	return m, nil
+}
+
+// startRolloutAction runs the rollout action chosen from the menu on
+// m.rolloutTarget; restarts are confirmed first
+func (m MainModel) startRolloutAction(action string) (MainModel, tea.Cmd) {
+	res, client := m.rolloutTarget, m.kubeClient
+	switch action {
+	case "restart":
+		m.modal.ShowConfirm("Restart Rollout", fmt.Sprintf("Roll out new pods of %s?", bulkTarget(res)), func() {
+			m.pending.Add(rolloutRestartCmd(client, res))
+		}, nil)
+		m.modal.Type = components.ModalWarning
+		m.showModal = true
+		return m, nil
+	case "pause", "resume":
+		return m, rolloutPauseCmd(client, res, action == "pause")
+	case "history":
+		ctx, id := m.requests.Start(requestRollout)
+		return m, tea.Batch(
+			m.widgets[2].SetLoading(fmt.Sprintf("Loading rollout history of %s...", res.Name)),
+			loadRolloutHistoryCmd(ctx, client, id, res),
+		)
+	}
+	return m, nil
+}
+
+// showForwardPrompt asks for the local port to forward to port of
+// m.forwardTarget, suggesting the same port number
+func (m MainModel) showForwardPrompt(port kubernetes.ForwardPort) (MainModel, tea.Cmd) {
+	m.forwardPort = port
+	hint := "Local port, or local:remote; 0 picks a free local port"
+	value := ""
+	if port.Port != 0 {
+		value = strconv.Itoa(int(port.Port))
+	} else {
+		hint = "No ports are declared; enter local:remote"
+	}
+	m.promptModal.Show(fmt.Sprintf("Port-forward %s", bulkTarget(m.forwardTarget)), hint, "8080:80", value)
+	m.promptModal.SetDimensions(m.width, m.height)
+	m.showPromptModal = true
+	m.promptAction = promptForwardPorts
+	return m, nil
+}
+
+// resourceTableTitle titles the resource table, e.g.
+// "pods in default -l app=web"
+func resourceTableTitle(resourceType, namespace string, sel kubernetes.Selector) string {
+	title := fmt.Sprintf("%s in %s", resourceType, namespaceDisplayFromQuery(namespace))
+	if !sel.IsEmpty() {
+		title += " " + sel.String()
+	}
+	return title
+}
+
+// showSelectorPrompt asks for the label selector of the table; the field
+// selector is asked for next.
+func (m MainModel) showSelectorPrompt() (MainModel, tea.Cmd) {
+	m.promptModal.Show("Label Selector", "e.g. app=web,tier!=cache (empty for none)", "app=web", m.tableSelector.Label)
+	m.promptModal.SetDimensions(m.width, m.height)
+	m.showPromptModal = true
+	m.promptAction = promptLabelSelector
+	return m, nil
+}
+
+// applySelector lists the table again, or restarts the watch, with sel
+func (m MainModel) applySelector(sel kubernetes.Selector) (MainModel, tea.Cmd) {
+	m.tableSelector = sel
+	if m.watching {
+		resourceType, namespace := m.watchResource, m.watchNamespace
+		m.stopWatch()
+		return m, m.startWatch(resourceType, namespace)
+	}
+	if m.kubeClient == nil || m.tableResource == "" {
+		return m, nil
+	}
+	return m, m.loadResources(m.tableResource, m.tableNamespace, false)
+}
+
+// loadAPIResources fetches the API resource list for the side panel
+func (m MainModel) loadAPIResources() tea.Cmd {
+	ctx, id := m.requests.Start(requestAPIResources)
+	return tea.Batch(
+		m.widgets[1].SetLoading("Loading API resources..."),
+		loadAPIResourcesCmd(ctx, m.kubeClient, id),
+	)
+}
+
+// loadNamespaces fetches the namespaces offered by the namespace selector
+func (m MainModel) loadNamespaces() tea.Cmd {
+	ctx, id := m.requests.Start(requestNamespaces)
+	return tea.Batch(
+		m.widgets[2].SetLoading("Loading namespaces..."),
+		loadNamespacesCmd(ctx, m.kubeClient, id),
+	)
+}
+
+// loadResources lists resources into the table. A refresh keeps the current
+// selection, otherwise the table starts over at the first row.
+func (m MainModel) loadResources(resourceType, namespace string, refresh bool) tea.Cmd {
+	ctx, id := m.requests.Start(requestResources)
+	label := fmt.Sprintf("Loading %s in %s...", resourceType, namespaceDisplayFromQuery(namespace))
+	return tea.Batch(
+		m.widgets[2].SetLoading(label),
+		loadResourcesCmd(ctx, m.kubeClient, id, resourceType, namespace, m.tableSelector, refresh),
+	)
+}
+
+// logTailLines is how many lines are fetched when a log is opened
+const logTailLines = 1000
+
+// logSinceCycle lists the sinceSeconds values the logs modal cycles through; 0 means no limit
+var logSinceCycle = []int64{0, 300, 900, 3600, 6 * 3600, 24 * 3600}
+
+func (m MainModel) loadPodContainers(res kubernetes.ResourceInfo) tea.Cmd {
+	ctx, id := m.requests.Start(requestLogs)
+	return tea.Batch(
+		m.widgets[2].SetLoading(fmt.Sprintf("Loading containers of %s...", res.Name)),
+		loadPodContainersCmd(ctx, m.kubeClient, id, res),
+	)
+}
+
+// openLogs (re)opens the logs of m.logTarget with m.logOptions. In follow mode
+// the modal opens right away and lines stream in; otherwise the logs are
+// fetched once and the modal opens when they arrive.
+func (m MainModel) openLogs() (MainModel, tea.Cmd) {
+	m.stopLogStream()
+	if m.logOptions.Follow {
+		m.requests.Cancel(requestLogs)
+		ctx, cancel := context.WithCancel(context.Background())
+		m.logCancel = cancel
+		m.logsModal.Show(fmt.Sprintf("%s - following", m.logsTitle()), "")
+		m.logsModal.SetStatus(logStatus(m.logOptions, m.logContainers))
+		m.logsModal.SetDimensions(m.width, m.height)
+		m.showLogsModal = true
+		return m, startLogStreamCmd(ctx, m.kubeClient, m.logSession, m.logTarget, m.logOptions)
+	}
+
+	ctx, id := m.requests.Start(requestLogs)
+	if m.showLogsModal {
+		m.logsModal.SetStatus(logStatus(m.logOptions, m.logContainers) + "  |  loading...")
+	}
+	return m, tea.Batch(
+		m.widgets[2].SetLoading(fmt.Sprintf("Loading logs for %s...", m.logTarget.Name)),
+		loadPodLogsCmd(ctx, m.kubeClient, id, m.logTarget, m.logOptions),
+	)
+}
+
+func (m MainModel) logsTitle() string {
+	target := m.logTarget.Name
+	if m.logOptions.Container != "" {
+		target = fmt.Sprintf("%s/%s", target, m.logOptions.Container)
+	}
+	return fmt.Sprintf("Pod Logs: %s (namespace: %s)", target, m.logTarget.Namespace)
+}
+
+// logStatus summarises the active log options for the logs modal
+func logStatus(opts kubernetes.LogOptions, containers []kubernetes.ContainerInfo) string {
+	onOff := func(v bool) string {
+		if v {
+			return "on"
+		}
+		return "off"
+	}
+	container := opts.Container
+	for _, c := range containers {
+		if c.Name == opts.Container && c.Kind != kubernetes.ContainerKindRegular {
+			container = fmt.Sprintf("%s (%s)", c.Name, c.Kind)
+		}
+	}
+	since := "all"
+	if opts.SinceTime != nil {
+		since = opts.SinceTime.Format(time.RFC3339)
+	} else if opts.SinceSeconds > 0 {
+		since = (time.Duration(opts.SinceSeconds) * time.Second).String()
+	}
+	status := fmt.Sprintf("[f] follow: %s  |  [p] previous: %s  |  [t] timestamps: %s  |  [s/S] since: %s",
+		onOff(opts.Follow), onOff(opts.Previous), onOff(opts.Timestamps), since)
+	if len(containers) > 1 {
+		return fmt.Sprintf("[c] container: %s  |  %s", container, status)
+	}
+	return fmt.Sprintf("container: %s  |  %s", container, status)
+}
+
+// containerMenuItems lists the containers of a pod with the default one first
+func containerMenuItems(containers []kubernetes.ContainerInfo) []components.MenuItem {
+	items := make([]components.MenuItem, 0, len(containers))
+	for _, c := range containers {
+		item := components.MenuItem{
+			Label:       c.Name,
+			Description: fmt.Sprintf("%s container, %s", c.Kind, c.State),
+			Value:       c.Name,
+		}
+		if c.Kind == kubernetes.ContainerKindRegular {
+			item.Description = c.State
+		}
+		if c.Default {
+			items = append([]components.MenuItem{item}, items...)
+		} else {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// showSavePrompt asks where to save content, suggesting a generated file name
+func (m MainModel) showSavePrompt(title, content, fileName string) (MainModel, tea.Cmd) {
+	m.exportContent = content
+	m.promptModal.Show(title, "File to write; tab toggles gzip (.gz)", fileName, fileName)
+	m.promptModal.SetDimensions(m.width, m.height)
+	m.showPromptModal = true
+	m.promptAction = promptSaveExport
+	return m, nil
+}
+
+// stopLogStream cancels a followed log. Lines still in flight for the old
+// session are ignored because the session counter moves on.
+func (m *MainModel) stopLogStream() {
+	if m.logCancel != nil {
+		m.logCancel()
+		m.logCancel = nil
+	}
+	m.logSession++
+}
+
+func (m MainModel) loadDescribe(resourceType, namespace, name string) tea.Cmd {
+	ctx, id := m.requests.Start(requestDescribe)
+	return tea.Batch(
+		m.widgets[2].SetLoading(fmt.Sprintf("Describing %s/%s...", resourceType, name)),
+		loadDescribeCmd(ctx, m.kubeClient, id, resourceType, namespace, name),
+	)
+}
+
+// finishRequest releases a request and reports whether its result is still
+// current. The spinner of the widget the request loads into is cleared once
+// nothing else is loading into that widget.
+func (m MainModel) finishRequest(slot requestSlot, id int) bool {
+	if !m.requests.Finish(slot, id) {
+		return false
+	}
+	if slot == requestAPIResources {
+		m.widgets[1].ClearLoading()
+		return true
+	}
+	for _, other := range []requestSlot{requestNamespaces, requestResources, requestLogs, requestDescribe, requestScale, requestRollout, requestExec, requestForward, requestEvents} {
+		if m.requests.Busy(other) {
+			return true
+		}
+	}
+	m.widgets[2].ClearLoading()
+	return true
+}
+
+// cancelRequests cancels every in-flight API call and clears all spinners
+func (m MainModel) cancelRequests() {
+	m.requests.CancelAll()
+	for _, w := range m.widgets {
+		w.ClearLoading()
+	}
+}
+
+// quit stops everything still talking to the cluster before exiting
+func (m MainModel) quit() (tea.Model, tea.Cmd) {
+	m.cancelRequests()
+	m.stopWatch()
+	m.stopLogStream()
+	m.stopForwards()
+	return m, tea.Quit
+}
+
+// stopForwards stops every port-forward and closes the forwards panel
+func (m *MainModel) stopForwards() {
+	for _, forward := range m.forwards {
+		forward.Stop()
+	}
+	m.forwards = nil
+	m.forwardsPanel.SetForwards(nil)
+	m.forwardsPanel.Hide()
+	m.showForwardsPanel = false
+}
+
+// finishEdit ends an edit of the object shown in the describe modal, showing
+// err if there is one, and reloads the describe output
+func (m MainModel) finishEdit(title string, err error) (MainModel, tea.Cmd) {
+	m.resourceEditActive = false
+	m.editObject = nil
+	m.editDraft = nil
+	m.describeModal.SetMode(components.DescribeModeRead)
+	m.showDescribeModal = true
+	if err != nil {
+		m.modal.ShowError(title, err.Error(), "Close")
+		m.showModal = true
+	}
+	rt, namespace, name := m.describeModal.TargetInfo()
+	if m.kubeClient == nil || !m.describeModal.CanEdit() {
+		return m, nil
+	}
+	return m, m.loadDescribe(rt, namespace, name)
+}
+
+// retryEdit handles an edit the server did not accept. Conflicts check out
+// the latest version with the user's changes on top, invalid changes reopen
+// the editor with the error; anything else ends the edit.
+func (m MainModel) retryEdit(edited []byte, err error) (MainModel, tea.Cmd) {
+	obj := m.editObject
+	switch {
+	case kubernetes.IsEditConflict(err):
+		failure := fmt.Errorf("%v\nThe object was changed on the server while you edited it. Saving again\noverwrites those changes with the content below.", err)
+		return m, loadEditObjectCmd(m.kubeClient, obj.ResourceType, obj.Namespace, obj.Name, edited, failure)
+	case kubernetes.IsEditInvalid(err):
+		return m.reopenEditor(edited, err)
+	}
+	return m.finishEdit("Edit Error", err)
+}
+
+// reopenEditor opens the editor on a manifest of m.editObject, with failure
+// as a comment above it
+func (m MainModel) reopenEditor(edited []byte, failure error) (MainModel, tea.Cmd) {
+	cmd, err := openEditorCmd(editManifestContent(edited, failure), func(path string, err error) tea.Msg {
+		return editEditorFinishedMsg{path: path, err: err}
+	})
+	if err != nil {
+		return m.finishEdit("Edit Error", err)
+	}
+	return m, cmd
+}
+
+// startCreateEditor opens the editor on a manifest for the create flow
+func (m MainModel) startCreateEditor(manifest string) (MainModel, tea.Cmd) {
+	cmd, err := openEditorCmd(createManifestHeader+manifest, func(path string, err error) tea.Msg {
+		return createEditorFinishedMsg{path: path, err: err}
+	})
+	if err != nil {
+		m.modal.ShowError("Create Error", err.Error(), "Close")
+		m.showModal = true
+		return m, nil
+	}
+	return m, cmd
+}
+
+func deleteConfirmMessage(res kubernetes.ResourceInfo, opts kubernetes.DeleteOptions) string {
+	target := fmt.Sprintf("%s/%s", normalizeResourceTypeForFetch(res.Type), res.Name)
+	if ns := strings.TrimSpace(res.Namespace); ns != "" {
+		target = fmt.Sprintf("%s in %s", target, ns)
+	}
+
+	grace := "default"
+	if opts.GracePeriodSeconds != nil {
+		grace = fmt.Sprintf("%ds", *opts.GracePeriodSeconds)
+	}
+	propagation := string(opts.PropagationPolicy)
+	if propagation == "" {
+		propagation = "default"
+	}
+	force := "no"
+	if opts.Force {
+		force = "yes"
+		grace = "0s"
+	}
 
-type kubectlEditFinishedMsg struct {
-	err error
+	lines := []string{
+		fmt.Sprintf("Delete %s?", target),
+		"",
+		fmt.Sprintf("Grace period: %s  (+/-)", grace),
+		fmt.Sprintf("Propagation: %s  (p)", propagation),
+		fmt.Sprintf("Force: %s  (f)", force),
+	}
+	if grace == "0s" {
+		lines = append(lines, "", "Warning: a 0s grace period removes the object immediately, without waiting for its processes to stop")
+	}
+	return strings.Join(lines, "\n")
 }
 
-func initialModel() MainModel {
+func initialModel(opts kubernetes.ClientOptions) MainModel {
 	widgets := []widgets.Widget{
 		widgets.NewNameSpaceWidget(),
 		widgets.NewApiResourceWidget(),
 		widgets.NewMainContentWidget(),
+		widgets.NewContextWidget(),
 	}
 
 	widgets[0].SetFocused(true)
 
-	kubeClient, err := kubernetes.NewKubeClient()
-	showModal := false
-	if err != nil {
-		fmt.Printf("Warning: Could not connect to Kubernetes: %v\n", err)
-		showModal = true
-	}
+	kubeClient, err := kubernetes.NewKubeClientWithOptions(opts)
 
 	if kubeClient != nil {
-		if apiResources, err := kubeClient.GetAPIResources(); err != nil {
-			fmt.Printf("Error fetching API resources: %v\n", err)
-			showModal = true
-		} else {
-			if arw, ok := widgets[1].(interface{ SetApiResourceList([]string) }); ok {
-				arw.SetApiResourceList(apiResources)
-			}
+		if cw, ok := widgets[3].(interface {
+			SetCurrentContext(string)
+			SetReadOnly(bool)
+		}); ok {
+			cw.SetCurrentContext(kubeClient.CurrentContext())
+			cw.SetReadOnly(kubeClient.ReadOnly())
+		}
+		if nsw, ok := widgets[0].(interface{ SetSelectedNameSpace(string) }); ok {
+			nsw.SetSelectedNameSpace(kubeClient.DefaultNamespace())
 		}
 	}
 
 	modal := components.NewModal()
 	logsModal := components.NewLogsModal()
 	describeModal := components.NewDescribeModal()
+	showModal := err != nil
 	if showModal {
-		modal.ShowError("Kubernetes Connection Failed", "Could not connect to Kubernetes cluster.\nPlease check your kubeconfig and cluster status.\nMake sure minikube is running: minikube start", "Ctrl+Q")
+		modal.ShowError("Kubernetes Connection Failed", fmt.Sprintf("Could not load the Kubernetes configuration:\n%v\n\nPlease check your kubeconfig and cluster status.", err), "Ctrl+Q")
 	}
 
 	return MainModel{
@@ -80,18 +635,55 @@ func initialModel() MainModel {
 		modal:             modal,
 		logsModal:         logsModal,
 		describeModal:     describeModal,
+		menuModal:         components.NewMenuModal(),
+		resultsModal:      components.NewResultsModal(),
+		forwardsPanel:     components.NewPortForwardsPanel(),
+		eventsPanel:       components.NewEventsPanel(),
+		diffModal:         components.NewDiffModal(),
+		promptModal:       components.NewPromptModal(),
 		showModal:         showModal,
 		showLogsModal:     false,
 		showDescribeModal: false,
+		clientOptions:     opts,
+		pending:           &pendingActions{},
+		requests:          newRequestTracker(),
 	}
 }
 
 func (m MainModel) Init() tea.Cmd {
-	return nil
+	if m.kubeClient == nil {
+		return nil
+	}
+	return m.loadAPIResources()
 }
 
-func watchTickCmd() tea.Cmd {
-	return tea.Tick(time.Second, func(_ time.Time) tea.Msg { return WatchTick{} })
+// startWatch starts watching resourceType in namespace with the table's selector
+func (m *MainModel) startWatch(resourceType, namespace string) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.watching = true
+	m.watchResource = resourceType
+	m.watchNamespace = namespace
+	m.watchCancel = cancel
+	if mainContent, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
+		mainContent.SetWatching(true)
+	}
+	return startWatchCmd(ctx, m.kubeClient, m.watchSession, resourceType, namespace, m.tableSelector)
+}
+
+// stopWatch cancels the running watch, if any. Events still in flight for the
+// old session are ignored because the session counter moves on.
+func (m *MainModel) stopWatch() {
+	if m.watchCancel != nil {
+		m.watchCancel()
+		m.watchCancel = nil
+	}
+	m.watchSession++
+	m.watching = false
+	m.watchResource = ""
+	m.watchNamespace = ""
+	if mainContent, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
+		mainContent.SetWatching(false)
+	}
 }
 
 func normalizeResourceTypeForFetch(rt string) string {
@@ -147,28 +739,6 @@ func determineKubectlEditor() string {
 	return ""
 }
 
-func applyEditorEnv(env []string, editor string) []string {
-	editor = strings.TrimSpace(editor)
-	if editor == "" {
-		return env
-	}
-	env = replaceOrAppendEnv(env, "KUBE_EDITOR", editor)
-	env = replaceOrAppendEnv(env, "EDITOR", editor)
-	return env
-}
-
-func replaceOrAppendEnv(env []string, key, value string) []string {
-	prefix := key + "="
-	filtered := make([]string, 0, len(env)+1)
-	for _, kv := range env {
-		if !strings.HasPrefix(kv, prefix) {
-			filtered = append(filtered, kv)
-		}
-	}
-	filtered = append(filtered, prefix+value)
-	return filtered
-}
-
 func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -176,44 +746,503 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 	case tea.KeyMsg:
+		if m.showResultsModal && !m.showModal {
+			switch msg.String() {
+			case "ctrl+q":
+				return m.quit()
+			case tea.KeyEscape.String(), tea.KeyEnter.String(), "q":
+				m.resultsModal.Hide()
+				m.showResultsModal = false
+			case "up", "k":
+				m.resultsModal.ScrollUp()
+			case "down", "j":
+				m.resultsModal.ScrollDown()
+			case "pgup":
+				m.resultsModal.PageUp()
+			case "pgdown":
+				m.resultsModal.PageDown()
+			}
+			return m, nil
+		}
+
+		if m.showDiffModal && !m.showModal {
+			switch msg.String() {
+			case "ctrl+q":
+				return m.quit()
+			case "y", tea.KeyEnter.String():
+				m.diffModal.Hide()
+				m.showDiffModal = false
+				return m, applyEditCmd(m.kubeClient, m.editObject, m.editDraft)
+			case "e":
+				m.diffModal.Hide()
+				m.showDiffModal = false
+				return m.reopenEditor(m.editDraft, nil)
+			case "n", tea.KeyEscape.String():
+				m.diffModal.Hide()
+				m.showDiffModal = false
+				m.modal.ShowInfo("Edit Discarded", "The changes were not applied")
+				m.showModal = true
+				return m.finishEdit("", nil)
+			case "up", "k":
+				m.diffModal.ScrollUp()
+			case "down", "j":
+				m.diffModal.ScrollDown()
+			case "pgup":
+				m.diffModal.PageUp()
+			case "pgdown":
+				m.diffModal.PageDown()
+			}
+			return m, nil
+		}
+
+		if m.showForwardsPanel && !m.showModal {
+			switch msg.String() {
+			case "ctrl+q":
+				return m.quit()
+			case tea.KeyEscape.String(), "q", "ctrl+p":
+				m.forwardsPanel.Hide()
+				m.showForwardsPanel = false
+			case "up", "k":
+				m.forwardsPanel.MoveUp()
+			case "down", "j":
+				m.forwardsPanel.MoveDown()
+			case "x", "d":
+				if forward := m.forwardsPanel.Selected(); forward != nil {
+					m.forwards = stopForward(m.forwards, forward)
+					m.forwardsPanel.SetForwards(m.forwards)
+				}
+			}
+			return m, nil
+		}
+
+		if m.showEventsPanel && !m.showModal {
+			switch msg.String() {
+			case "ctrl+q":
+				return m.quit()
+			case tea.KeyEscape.String(), "q", "ctrl+g":
+				m.eventsPanel.Hide()
+				m.showEventsPanel = false
+			case "up", "k":
+				m.eventsPanel.MoveUp()
+			case "down", "j":
+				m.eventsPanel.MoveDown()
+			case "pgup":
+				m.eventsPanel.PageUp()
+			case "pgdown":
+				m.eventsPanel.PageDown()
+			case "w":
+				m.eventsPanel.ToggleWarnings()
+			case "r":
+				return m, m.loadEvents(m.eventsPanel.Namespace)
+			case tea.KeyEnter.String():
+				if event := m.eventsPanel.Selected(); event != nil {
+					ctx, id := m.requests.Start(requestEvents)
+					return m, tea.Batch(
+						m.widgets[2].SetLoading(fmt.Sprintf("Finding %s %s...", event.Object.Kind, event.Object.Name)),
+						resolveEventObjectCmd(ctx, m.kubeClient, id, event.Object),
+					)
+				}
+			}
+			return m, nil
+		}
+
+		if m.showPromptModal && !m.showModal {
+			switch msg.String() {
+			case "ctrl+q":
+				return m.quit()
+			case tea.KeyEscape.String():
+				m.promptModal.Hide()
+				m.showPromptModal = false
+				m.promptAction = promptNone
+				m.exportContent = ""
+				m.bulkTargets = nil
+				return m, nil
+			case tea.KeyTab.String():
+				if m.promptAction == promptSaveExport {
+					m.promptModal.SetValue(toggleGzipSuffix(m.promptModal.Value()))
+					return m, nil
+				}
+			case tea.KeyEnter.String():
+				value := strings.TrimSpace(m.promptModal.Value())
+				action := m.promptAction
+				m.promptModal.Hide()
+				m.showPromptModal = false
+				m.promptAction = promptNone
+				switch action {
+				case promptCreateFromFile:
+					if value == "" {
+						return m, nil
+					}
+					data, err := os.ReadFile(value)
+					if err != nil {
+						m.modal.ShowError("Create Error", fmt.Sprintf("Failed to read %s:\n%v", value, err), "Close")
+						m.showModal = true
+						return m, nil
+					}
+					return m.startCreateEditor(string(data))
+				case promptLogSinceTime:
+					if value == "" {
+						m.logOptions.SinceTime = nil
+						return m.openLogs()
+					}
+					since, err := time.Parse(time.RFC3339, value)
+					if err != nil {
+						m.modal.ShowError("Logs Error", fmt.Sprintf("Invalid time %q, expected RFC3339 such as 2024-01-02T15:04:05Z:\n%v", value, err), "Close")
+						m.showModal = true
+						return m, nil
+					}
+					m.logOptions.SinceTime = &since
+					m.logOptions.SinceSeconds = 0
+					return m.openLogs()
+				case promptSaveExport:
+					content := m.exportContent
+					m.exportContent = ""
+					if value == "" {
+						return m, nil
+					}
+					return m, saveExportCmd(value, content)
+				case promptLabelSelector:
+					m.selectorDraft = value
+					m.promptModal.Show("Field Selector", "e.g. status.phase=Running,spec.nodeName=node-1 (empty for none)", "status.phase=Running", m.tableSelector.Field)
+					m.promptModal.SetDimensions(m.width, m.height)
+					m.showPromptModal = true
+					m.promptAction = promptFieldSelector
+					return m, nil
+				case promptBulkLabel, promptBulkAnnotate:
+					targets := m.bulkTargets
+					m.bulkTargets = nil
+					changes, err := kubernetes.ParseMetadataChanges(value)
+					if err != nil {
+						m.modal.ShowError("Bulk Action Error", err.Error(), "Close")
+						m.showModal = true
+						return m, nil
+					}
+					if action == promptBulkLabel {
+						return m, bulkLabelCmd(m.kubeClient, targets, changes)
+					}
+					return m, bulkAnnotateCmd(m.kubeClient, targets, changes)
+				case promptBulkScale:
+					targets := m.bulkTargets
+					m.bulkTargets = nil
+					replicas, err := parseReplicas(value)
+					if err != nil {
+						m.modal.ShowError("Bulk Action Error", err.Error(), "Close")
+						m.showModal = true
+						return m, nil
+					}
+					return m, bulkScaleCmd(m.kubeClient, targets, replicas)
+				case promptForwardPorts:
+					local, remote, err := parseForwardPorts(value, m.forwardPort.Port)
+					if err != nil {
+						m.modal.ShowError("Port-forward Error", err.Error(), "Close")
+						m.showModal = true
+						return m, nil
+					}
+					port := m.forwardPort
+					if remote != port.Port {
+						port = kubernetes.ForwardPort{Port: remote}
+					}
+					return m, startPortForwardCmd(m.kubeClient, m.forwardTarget, port, local)
+				case promptScale:
+					replicas, err := parseReplicas(value)
+					if err != nil {
+						m.modal.ShowError("Scale Error", err.Error(), "Close")
+						m.showModal = true
+						return m, nil
+					}
+					return m, scaleResourceCmd(m.kubeClient, m.scaleTarget, replicas)
+				case promptFieldSelector:
+					sel, err := kubernetes.ParseSelector(m.selectorDraft, value)
+					m.selectorDraft = ""
+					if err != nil {
+						m.modal.ShowError("Selector Error", err.Error(), "Close")
+						m.showModal = true
+						return m, nil
+					}
+					return m.applySelector(sel)
+				}
+				return m, nil
+			}
+			return m, m.promptModal.Update(msg)
+		}
+
+		if m.showMenuModal && !m.showModal {
+			switch msg.String() {
+			case "ctrl+q":
+				return m.quit()
+			case tea.KeyEscape.String():
+				m.menuModal.Hide()
+				m.showMenuModal = false
+				m.menuAction = menuNone
+			case "up", "k":
+				m.menuModal.MoveUp()
+			case "down", "j":
+				m.menuModal.MoveDown()
+			case tea.KeyEnter.String():
+				item := m.menuModal.Selected()
+				action := m.menuAction
+				m.menuModal.Hide()
+				m.showMenuModal = false
+				m.menuAction = menuNone
+				if item == nil {
+					return m, nil
+				}
+				switch action {
+				case menuCreateSource:
+					switch {
+					case strings.HasPrefix(item.Value, "template:"):
+						manifest, err := templates.Get(strings.TrimPrefix(item.Value, "template:"))
+						if err != nil {
+							m.modal.ShowError("Create Error", err.Error(), "Close")
+							m.showModal = true
+							return m, nil
+						}
+						return m.startCreateEditor(manifest)
+					case item.Value == "clipboard":
+						manifest, err := clipboard.ReadAll()
+						if err != nil || strings.TrimSpace(manifest) == "" {
+							if err == nil {
+								err = fmt.Errorf("clipboard is empty")
+							}
+							m.modal.ShowError("Create Error", fmt.Sprintf("Failed to read clipboard:\n%v", err), "Close")
+							m.showModal = true
+							return m, nil
+						}
+						return m.startCreateEditor(manifest)
+					case item.Value == "file":
+						m.promptModal.Show("Create From File", "Path to a YAML or JSON manifest", "./manifest.yaml", "")
+						m.promptModal.SetDimensions(m.width, m.height)
+						m.showPromptModal = true
+						m.promptAction = promptCreateFromFile
+						return m, nil
+					}
+				case menuLogContainer:
+					m.logOptions.Container = item.Value
+					return m.openLogs()
+				case menuBulkAction:
+					return m.startBulkAction(item.Value)
+				case menuRollout:
+					return m.startRolloutAction(item.Value)
+				case menuForwardPort:
+					index, err := strconv.Atoi(item.Value)
+					if err != nil || index < 0 || index >= len(m.forwardPorts) {
+						return m, nil
+					}
+					return m.showForwardPrompt(m.forwardPorts[index])
+				case menuExecContainer:
+					return m, shellCmd(m.kubeClient, m.shellTarget, item.Value)
+				case menuRolloutRevision:
+					revision, err := strconv.ParseInt(item.Value, 10, 64)
+					if err != nil {
+						return m, nil
+					}
+					if m.readOnly() {
+						m.modal.ShowError("Read-only Mode", "Rolling back is disabled because l8zykube was started with --readonly", "Close")
+						m.showModal = true
+						return m, nil
+					}
+					res, client := m.rolloutTarget, m.kubeClient
+					m.modal.ShowConfirm("Undo Rollout", fmt.Sprintf("Roll %s back to revision %d?", bulkTarget(res), revision), func() {
+						m.pending.Add(rolloutUndoCmd(client, res, revision))
+					}, nil)
+					m.modal.Type = components.ModalWarning
+					m.showModal = true
+					return m, nil
+				}
+			}
+			return m, nil
+		}
+
+		if m.showLogsModal && !m.showModal {
+			if m.logsModal.HandleKey(msg) {
+				return m, nil
+			}
+			handled := true
+			switch msg.String() {
+			case "ctrl+s":
+				if m.logsModal.LineCount() == 0 {
+					m.logsModal.SetNotice("Nothing to save")
+					return m, nil
+				}
+				return m.showSavePrompt("Save Logs", m.logsModal.Content(), exportFileName(m.logTarget.Namespace, m.logTarget.Name, ".log", time.Now()))
+			case "c":
+				if len(m.logContainers) < 2 {
+					return m, nil
+				}
+				m.menuModal.Show("Select Container", containerMenuItems(m.logContainers))
+				m.menuModal.SetDimensions(m.width, m.height)
+				m.showMenuModal = true
+				m.menuAction = menuLogContainer
+				return m, nil
+			case "f":
+				m.logOptions.Follow = !m.logOptions.Follow
+			case "p":
+				m.logOptions.Previous = !m.logOptions.Previous
+			case "t":
+				m.logOptions.Timestamps = !m.logOptions.Timestamps
+			case "s":
+				next := 0
+				for i, seconds := range logSinceCycle {
+					if seconds == m.logOptions.SinceSeconds {
+						next = (i + 1) % len(logSinceCycle)
+					}
+				}
+				m.logOptions.SinceSeconds = logSinceCycle[next]
+				m.logOptions.SinceTime = nil
+			case "S":
+				value := ""
+				if m.logOptions.SinceTime != nil {
+					value = m.logOptions.SinceTime.Format(time.RFC3339)
+				}
+				m.promptModal.Show("Logs Since", "RFC3339 time, e.g. 2024-01-02T15:04:05Z (empty for no limit)", time.Now().UTC().Add(-time.Hour).Format(time.RFC3339), value)
+				m.promptModal.SetDimensions(m.width, m.height)
+				m.showPromptModal = true
+				m.promptAction = promptLogSinceTime
+				return m, nil
+			default:
+				handled = false
+			}
+			if handled {
+				return m.openLogs()
+			}
+		}
+
+		if m.showDescribeModal && !m.showModal && !m.resourceEditActive && msg.String() == "ctrl+s" {
+			_, namespace, name := m.describeModal.TargetInfo()
+			ext := ".txt"
+			if m.describeModal.ShowingYAML() {
+				ext = ".yaml"
+			}
+			return m.showSavePrompt("Save Describe Output", m.describeModal.Content, exportFileName(namespace, name, ext, time.Now()))
+		}
+
+		if m.showDescribeModal && !m.showModal && !m.resourceEditActive && msg.String() == "y" {
+			m.describeModal.ToggleYAML()
+			return m, nil
+		}
+
+		if m.showModal {
+			key := msg.String()
+			if key == tea.KeyEnter.String() {
+				m.modal.SelectButton()
+				m.showModal = false
+				m.deleteTarget = nil
+				return m, m.pending.Flush()
+			}
+			if m.deleteTarget != nil {
+				handled := true
+				switch key {
+				case "f":
+					m.deleteOptions.Force = !m.deleteOptions.Force
+				case "p":
+					next := 0
+					for i, policy := range deletePropagationCycle {
+						if policy == m.deleteOptions.PropagationPolicy {
+							next = (i + 1) % len(deletePropagationCycle)
+						}
+					}
+					m.deleteOptions.PropagationPolicy = deletePropagationCycle[next]
+				case "+", "=":
+					// A grace period of 0 is a force delete, which only f
+					// selects, so the shortest one offered is 1s
+					grace := int64(1)
+					if m.deleteOptions.GracePeriodSeconds != nil {
+						grace = *m.deleteOptions.GracePeriodSeconds + 5
+					}
+					m.deleteOptions.GracePeriodSeconds = &grace
+				case "-":
+					if g := m.deleteOptions.GracePeriodSeconds; g != nil {
+						if *g <= 1 {
+							m.deleteOptions.GracePeriodSeconds = nil
+						} else {
+							grace := *g - 5
+							if grace < 1 {
+								grace = 1
+							}
+							m.deleteOptions.GracePeriodSeconds = &grace
+						}
+					}
+				default:
+					handled = false
+				}
+				if handled {
+					m.modal.SetMessage(deleteConfirmMessage(*m.deleteTarget, m.deleteOptions))
+					target, opts, client := *m.deleteTarget, m.deleteOptions, m.kubeClient
+					m.modal.OnConfirm = func() {
+						m.pending.Add(deleteResourceCmd(client, target, opts))
+					}
+					return m, nil
+				}
+			}
+		}
+
+		// While the table filter is typed every key goes to it
+		if !m.showModal && !m.showDescribeModal && !m.showLogsModal && !m.resourceEditActive && msg.String() != "ctrl+q" {
+			if mcw, ok := m.widgets[2].(*widgets.MainContentWidget); ok && m.focusedWidget == 2 && mcw.IsFilterInputActive() {
+				var cmd tea.Cmd
+				m.widgets[2], cmd = m.widgets[2].Update(msg)
+				return m, cmd
+			}
+		}
+
 		switch msg.String() {
+		case "ctrl+f":
+			if m.showDescribeModal || m.showLogsModal || m.showModal || m.resourceEditActive {
+				return m, nil
+			}
+			if m.focusedWidget == 2 && m.tableResource != "" {
+				if mcw, ok := m.widgets[2].(*widgets.MainContentWidget); ok && !mcw.SelectionNameSpace && !mcw.SelectionContext {
+					return m.showSelectorPrompt()
+				}
+			}
+			return m, nil
+
 		case "ctrl+q":
 			if m.showModal {
 				m.modal.Hide()
 				m.showModal = false
-				return m, tea.Quit
+				return m.quit()
 			}
 			if m.showDescribeModal {
 				m.describeModal.Hide()
 				m.showDescribeModal = false
-				m.runningKubectlEdit = false
-				return m, tea.Quit
+				m.resourceEditActive = false
+				return m.quit()
 			}
 			if m.showLogsModal {
 				m.logsModal.Hide()
 				m.showLogsModal = false
-				return m, tea.Quit
+				return m.quit()
 			}
-			return m, tea.Quit
+			return m.quit()
 
 		case tea.KeyEscape.String():
 			if m.showModal {
 				m.modal.Hide()
 				m.showModal = false
+				m.deleteTarget = nil
 				return m, nil
 			}
+			if m.requests.Pending() {
+				// The first esc cancels whatever is still loading
+				m.cancelRequests()
+				if !m.showDescribeModal && !m.showLogsModal {
+					return m, nil
+				}
+			}
 			if m.showDescribeModal {
 				m.describeModal.Hide()
 				m.showDescribeModal = false
-				m.runningKubectlEdit = false
+				m.resourceEditActive = false
 				return m, nil
 			}
 			if m.showLogsModal {
+				m.stopLogStream()
 				m.logsModal.Hide()
 				m.showLogsModal = false
 				return m, nil
 			}
-			if m.runningKubectlEdit {
+			if m.resourceEditActive {
 				return m, nil
 			}
 			if apiResourceWidget, ok := m.widgets[1].(*widgets.ApiResourceWidget); ok {
@@ -231,6 +1260,13 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.focusedWidget = 0
 					return m, nil
 				}
+				if mainContentWidget.SelectionContext {
+					mainContentWidget.SetSelectionContext(false)
+					m.widgets[2].SetFocused(false)
+					m.widgets[3].SetFocused(true)
+					m.focusedWidget = 3
+					return m, nil
+				}
 			}
 			if m.focusedWidget < len(m.widgets) {
 				var cmd tea.Cmd
@@ -238,31 +1274,67 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, cmd
 			}
 
+		case "ctrl+p":
+			if m.showDescribeModal || m.showLogsModal || m.showModal || m.resourceEditActive {
+				return m, nil
+			}
+			m.forwardsPanel.Show(m.forwards)
+			m.forwardsPanel.SetDimensions(m.width, m.height)
+			m.showForwardsPanel = true
+			return m, forwardsTickCmd()
+
+		case "ctrl+g":
+			if m.showDescribeModal || m.showLogsModal || m.showModal || m.resourceEditActive {
+				return m, nil
+			}
+			if m.kubeClient == nil {
+				m.modal.ShowError("No Connection", "Not connected to Kubernetes cluster", "Close")
+				m.showModal = true
+				return m, nil
+			}
+			namespace := m.tableNamespace
+			if m.tableResource == "" {
+				if namespaceWidget, ok := m.widgets[0].(*widgets.NameSpaceWidget); ok {
+					namespace, _ = resolveNamespaceSelection(namespaceWidget.GetSelectedNameSpace())
+				}
+			}
+			return m, m.loadEvents(namespace)
+
+		case "ctrl+n":
+			if m.showDescribeModal || m.showLogsModal || m.showModal || m.resourceEditActive {
+				return m, nil
+			}
+			if m.kubeClient == nil {
+				m.modal.ShowError("No Connection", "Not connected to Kubernetes cluster", "Close")
+				m.showModal = true
+				return m, nil
+			}
+			if m.readOnly() {
+				m.modal.ShowError("Read-only Mode", "Creating resources is disabled because l8zykube was started with --readonly", "Close")
+				m.showModal = true
+				return m, nil
+			}
+			m.menuModal.Show("Create Resource", createSourceMenuItems())
+			m.menuModal.SetDimensions(m.width, m.height)
+			m.showMenuModal = true
+			m.menuAction = menuCreateSource
+			return m, nil
+
 		case "ctrl+e":
-			if m.showDescribeModal && !m.runningKubectlEdit {
+			if m.showDescribeModal && !m.resourceEditActive && m.readOnly() {
+				m.modal.ShowError("Read-only Mode", "Editing is disabled because l8zykube was started with --readonly", "Close")
+				m.showModal = true
+				return m, nil
+			}
+			if m.showDescribeModal && !m.resourceEditActive && m.kubeClient != nil {
 				if m.describeModal.Mode() == components.DescribeModeRead && m.describeModal.CanEdit() {
-					args := m.describeModal.EditCommandArgs()
-					if len(args) >= 2 {
-						m.describeModal.SetMode(components.DescribeModeWrite)
-						m.runningKubectlEdit = true
-						cmd := exec.Command(args[0], args[1:]...)
-						env := os.Environ()
-						editor := determineKubectlEditor()
-						env = applyEditorEnv(env, editor)
-						cmd.Env = env
-						cmd.Stdin = os.Stdin
-						cmd.Stdout = os.Stdout
-						cmd.Stderr = os.Stderr
-						return m, tea.Batch(
-							tea.ExitAltScreen,
-							tea.ExecProcess(cmd, func(err error) tea.Msg {
-								return kubectlEditFinishedMsg{err: err}
-							}),
-						)
-					}
+					rt, namespace, name := m.describeModal.TargetInfo()
+					m.describeModal.SetMode(components.DescribeModeWrite)
+					m.resourceEditActive = true
+					return m, loadEditObjectCmd(m.kubeClient, rt, namespace, name, nil, nil)
 				}
 			}
-			if m.runningKubectlEdit {
+			if m.resourceEditActive {
 				return m, nil
 			}
 
@@ -275,7 +1347,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logsModal.ScrollUp()
 				return m, nil
 			}
-			if m.runningKubectlEdit {
+			if m.resourceEditActive {
 				return m, nil
 			}
 
@@ -288,7 +1360,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logsModal.ScrollDown()
 				return m, nil
 			}
-			if m.runningKubectlEdit {
+			if m.resourceEditActive {
 				return m, nil
 			}
 
@@ -301,7 +1373,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logsModal.PageUp()
 				return m, nil
 			}
-			if m.runningKubectlEdit {
+			if m.resourceEditActive {
 				return m, nil
 			}
 
@@ -314,7 +1386,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logsModal.PageDown()
 				return m, nil
 			}
-			if m.runningKubectlEdit {
+			if m.resourceEditActive {
 				return m, nil
 			}
 
@@ -327,7 +1399,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logsModal.ScrollToTop()
 				return m, nil
 			}
-			if m.runningKubectlEdit {
+			if m.resourceEditActive {
 				return m, nil
 			}
 
@@ -340,7 +1412,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logsModal.ScrollToBottom()
 				return m, nil
 			}
-			if m.runningKubectlEdit {
+			if m.resourceEditActive {
 				return m, nil
 			}
 
@@ -373,12 +1445,12 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 			}
-			if m.runningKubectlEdit {
+			if m.resourceEditActive {
 				return m, nil
 			}
 
 			if mainContentWidget, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
-				if m.focusedWidget == 2 && (mainContentWidget.SelectionNameSpace || mainContentWidget.IsResourcesActive()) {
+				if m.focusedWidget == 2 && (mainContentWidget.SelectionNameSpace || mainContentWidget.SelectionContext || mainContentWidget.IsResourcesActive()) {
 					var cmd tea.Cmd
 					m.widgets[m.focusedWidget], cmd = m.widgets[m.focusedWidget].Update(msg)
 					return m, cmd
@@ -410,7 +1482,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		default:
-			if m.showDescribeModal || m.runningKubectlEdit {
+			if m.showDescribeModal || m.resourceEditActive {
 				return m, nil
 			}
 
@@ -426,19 +1498,82 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							if namespaceWidget, ok := m.widgets[0].(*widgets.NameSpaceWidget); ok {
 								selectedNamespace = namespaceWidget.GetSelectedNameSpace()
 							}
-							queryNS, displayNS := resolveNamespaceSelection(selectedNamespace)
-							resources, err := m.kubeClient.GetResourceListDetailed(selectedResource, queryNS)
-							if err != nil {
-								fmt.Printf("Error fetching %s in %s: %v\n", selectedResource, displayNS, err)
-							} else if mainContent, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
-								mainContent.SetResourcesDetailed(fmt.Sprintf("%s in %s", selectedResource, displayNS), resources)
-							}
+							queryNS, _ := resolveNamespaceSelection(selectedNamespace)
+							m.stopWatch()
+							m.tableResource = selectedResource
+							m.tableNamespace = queryNS
+							m.tableSelector = kubernetes.Selector{}
+							return m, tea.Batch(cmd, m.loadResources(selectedResource, queryNS, false))
 						}
 					}
 					return m, cmd
 				}
 			}
 
+			if contextWidget, ok := m.widgets[3].(*widgets.ContextWidget); ok {
+				if mainContentWidget, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
+					if m.focusedWidget == 3 && msg.String() == tea.KeyEnter.String() {
+						contexts, err := kubernetes.ListContexts(m.clientOptions.Kubeconfig)
+						if err != nil {
+							m.modal.ShowError("Context Error", fmt.Sprintf("Failed to read kubeconfig contexts:\n%v", err), "Close")
+							m.showModal = true
+							return m, nil
+						}
+						mainContentWidget.SetContextList(contexts)
+						mainContentWidget.SetSelectionContext(true)
+						m.widgets[3].SetFocused(false)
+						m.widgets[2].SetFocused(true)
+						m.focusedWidget = 2
+						return m, nil
+					}
+
+					if m.focusedWidget == 2 && mainContentWidget.SelectionContext && msg.String() == tea.KeyEnter.String() {
+						selectedContext := mainContentWidget.GetSelectedContext()
+						if selectedContext == "" {
+							return m, nil
+						}
+						mainContentWidget.SetSelectionContext(false)
+						m.widgets[2].SetFocused(false)
+						m.widgets[3].SetFocused(true)
+						m.focusedWidget = 3
+
+						if m.kubeClient != nil && selectedContext == m.kubeClient.CurrentContext() {
+							return m, nil
+						}
+						opts := m.clientOptions
+						opts.Context = selectedContext
+						opts.Namespace = ""
+						newClient, err := kubernetes.NewKubeClientWithOptions(opts)
+						if err != nil {
+							m.modal.ShowError("Context Error", fmt.Sprintf("Failed to switch to context %s:\n%v", selectedContext, err), "Close")
+							m.showModal = true
+							return m, nil
+						}
+
+						// Port-forwards reach into the cluster being left
+						stopped := len(m.forwards)
+						m.stopForwards()
+						m.cancelRequests()
+						m.kubeClient = newClient
+						m.clientOptions = opts
+						m.stopWatch()
+						m.tableResource = ""
+						m.tableNamespace = ""
+						m.tableSelector = kubernetes.Selector{}
+						contextWidget.SetCurrentContext(newClient.CurrentContext())
+						mainContentWidget.ClearResources()
+						if namespaceWidget, ok := m.widgets[0].(*widgets.NameSpaceWidget); ok {
+							namespaceWidget.SetSelectedNameSpace(newClient.DefaultNamespace())
+						}
+						if stopped > 0 {
+							m.modal.ShowInfo("Port-forwards Stopped", fmt.Sprintf("Stopped %d port-forward(s) of the previous context", stopped))
+							m.showModal = true
+						}
+						return m, tea.Batch(m.loadNamespaces(), m.loadAPIResources())
+					}
+				}
+			}
+
 			if namespaceWidget, ok := m.widgets[0].(*widgets.NameSpaceWidget); ok {
 				if mainContentWidget, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
 					if m.focusedWidget == 0 && msg.String() == tea.KeyEnter.String() {
@@ -449,12 +1584,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						mainContentWidget.SetSelectionNameSpace(true)
 
 						if m.kubeClient != nil {
-							namespaces, err := m.kubeClient.GetNamespaces()
-							if err != nil {
-								fmt.Printf("Error fetching namespaces: %v\n", err)
-							} else {
-								mainContentWidget.SetNamespaceList(namespaces)
-							}
+							return m, m.loadNamespaces()
 						}
 						return m, nil
 					}
@@ -465,20 +1595,12 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							namespaceWidget.SetSelectedNameSpace(selectedNS)
 							mainContentWidget.SetSelectionNameSpace(false)
 
-							if m.kubeClient != nil {
-								if apiResourceWidget, ok := m.widgets[1].(*widgets.ApiResourceWidget); ok {
-									apiResources, err := m.kubeClient.GetAPIResources()
-									if err != nil {
-										fmt.Printf("Error fetching API resources: %v\n", err)
-									} else {
-										apiResourceWidget.SetApiResourceList(apiResources)
-									}
-								}
-							}
-
 							m.widgets[2].SetFocused(false)
 							m.widgets[0].SetFocused(true)
 							m.focusedWidget = 0
+							if m.kubeClient != nil {
+								return m, m.loadAPIResources()
+							}
 							return m, nil
 						}
 					}
@@ -494,83 +1616,478 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-			m.widgets[m.focusedWidget], cmd = m.widgets[m.focusedWidget].Update(msg)
-			return m, cmd
+			m.widgets[m.focusedWidget], cmd = m.widgets[m.focusedWidget].Update(msg)
+			return m, cmd
+		}
+	}
+
+	switch msg := msg.(type) {
+	case widgets.ShowLogsRequest:
+		if m.kubeClient == nil {
+			m.modal.ShowError("No Connection", "Not connected to Kubernetes cluster", "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if strings.EqualFold(msg.Resource.Type, "Pod") || strings.EqualFold(msg.Resource.Type, "Pods") {
+			m.stopLogStream()
+			m.logTarget = msg.Resource
+			m.logOptions = kubernetes.LogOptions{TailLines: logTailLines}
+			m.logContainers = nil
+			m.logsModal.ClearSearch()
+			return m, m.loadPodContainers(msg.Resource)
+		}
+		m.modal.ShowError("No Pod Selected", "Please select a pod to view logs", "Close")
+		m.showModal = true
+		return m, nil
+
+	case widgets.PortForwardRequest:
+		if m.kubeClient == nil {
+			m.modal.ShowError("No Connection", "Not connected to Kubernetes cluster", "Close")
+			m.showModal = true
+			return m, nil
+		}
+		resourceType := normalizeResourceTypeForFetch(msg.Resource.Type)
+		if !forwardResources[resourceType] {
+			m.modal.ShowError("Port-forward", fmt.Sprintf("Cannot forward ports of %s; select a pod, service or deployment", resourceType), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		ctx, id := m.requests.Start(requestForward)
+		return m, tea.Batch(
+			m.widgets[2].SetLoading(fmt.Sprintf("Loading ports of %s...", msg.Resource.Name)),
+			loadForwardPortsCmd(ctx, m.kubeClient, id, msg.Resource),
+		)
+
+	case forwardPortsLoadedMsg:
+		if !m.finishRequest(requestForward, msg.id) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.modal.ShowError("Port-forward Error", msg.err.Error(), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		m.forwardTarget = msg.resource
+		m.forwardPorts = msg.ports
+		switch len(msg.ports) {
+		case 0:
+			return m.showForwardPrompt(kubernetes.ForwardPort{})
+		case 1:
+			return m.showForwardPrompt(msg.ports[0])
+		}
+		m.menuModal.Show(fmt.Sprintf("Port-forward: %s - select port", msg.resource.Name), forwardPortMenuItems(msg.ports))
+		m.menuModal.SetDimensions(m.width, m.height)
+		m.showMenuModal = true
+		m.menuAction = menuForwardPort
+		return m, nil
+
+	case portForwardStartedMsg:
+		// A forward that finished starting after a context switch belongs to
+		// the cluster that was left
+		if msg.client != m.kubeClient {
+			if msg.forward != nil {
+				msg.forward.Stop()
+			}
+			return m, nil
+		}
+		if msg.err != nil {
+			m.modal.ShowError("Port-forward Error", msg.err.Error(), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		m.forwards = append(m.forwards, msg.forward)
+		m.forwardsPanel.Show(m.forwards)
+		m.forwardsPanel.SelectedIndex = len(m.forwards) - 1
+		m.forwardsPanel.SetDimensions(m.width, m.height)
+		m.showForwardsPanel = true
+		return m, forwardsTickCmd()
+
+	case eventsLoadedMsg:
+		if !m.finishRequest(requestEvents, msg.id) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.modal.ShowError("Events Error", msg.err.Error(), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if m.showEventsPanel {
+			m.eventsPanel.SetEvents(msg.events)
+			return m, nil
+		}
+		m.eventsPanel.Show(msg.namespace, msg.events)
+		m.eventsPanel.SetDimensions(m.width, m.height)
+		m.showEventsPanel = true
+		return m, nil
+
+	case eventObjectResolvedMsg:
+		if !m.finishRequest(requestEvents, msg.id) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.modal.ShowError("Events Error", fmt.Sprintf("Cannot show %s %s:\n%v", msg.object.Kind, msg.object.Name, msg.err), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		m.eventsPanel.Hide()
+		m.showEventsPanel = false
+		namespace := msg.object.Namespace
+		if namespace == "" {
+			namespace = metav1.NamespaceAll
+		} else if namespaceWidget, ok := m.widgets[0].(*widgets.NameSpaceWidget); ok {
+			namespaceWidget.SetSelectedNameSpace(namespace)
+		}
+		m.stopWatch()
+		m.tableResource = msg.resourceType
+		m.tableNamespace = namespace
+		m.tableSelector = kubernetes.Selector{}
+		m.jumpTarget = kubernetes.ResourceInfo{Type: msg.resourceType, Namespace: msg.object.Namespace, Name: msg.object.Name}
+		m.widgets[m.focusedWidget].SetFocused(false)
+		m.focusedWidget = 2
+		m.widgets[m.focusedWidget].SetFocused(true)
+		return m, m.loadResources(msg.resourceType, namespace, false)
+
+	case forwardsTickMsg:
+		// Returning is enough to redraw the counters
+		if !m.showForwardsPanel {
+			return m, nil
+		}
+		return m, forwardsTickCmd()
+
+	case widgets.ShellRequest:
+		if m.kubeClient == nil {
+			m.modal.ShowError("No Connection", "Not connected to Kubernetes cluster", "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if !strings.EqualFold(msg.Resource.Type, "Pod") && !strings.EqualFold(msg.Resource.Type, "Pods") {
+			m.modal.ShowError("No Pod Selected", "Please select a pod to open a shell in", "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if m.readOnly() {
+			m.modal.ShowError("Read-only Mode", "Shells are disabled because l8zykube was started with --readonly", "Close")
+			m.showModal = true
+			return m, nil
+		}
+		ctx, id := m.requests.Start(requestExec)
+		return m, tea.Batch(
+			m.widgets[2].SetLoading(fmt.Sprintf("Loading containers of %s...", msg.Resource.Name)),
+			loadExecContainersCmd(ctx, m.kubeClient, id, msg.Resource),
+		)
+
+	case execContainersLoadedMsg:
+		if !m.finishRequest(requestExec, msg.id) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.modal.ShowError("Shell Error", fmt.Sprintf("Failed to get containers:\n%v", msg.err), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if len(msg.containers) == 0 {
+			m.modal.ShowError("Shell Error", fmt.Sprintf("Pod %s has no running containers", msg.resource.Name), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if len(msg.containers) == 1 {
+			return m, shellCmd(m.kubeClient, msg.resource, msg.containers[0].Name)
+		}
+		m.shellTarget = msg.resource
+		m.menuModal.Show(fmt.Sprintf("Shell: %s - select container", msg.resource.Name), containerMenuItems(msg.containers))
+		m.menuModal.SetDimensions(m.width, m.height)
+		m.showMenuModal = true
+		m.menuAction = menuExecContainer
+		return m, nil
+
+	case shellExitedMsg:
+		if msg.err != nil {
+			m.modal.ShowError("Shell Error", fmt.Sprintf("Shell in %s/%s failed:\n%v", msg.resource.Name, msg.container, msg.err), "Close")
+			m.showModal = true
+		}
+		return m, tea.EnterAltScreen
+
+	case widgets.ToggleWatchRequest:
+		if m.kubeClient == nil {
+			m.modal.ShowError("No Connection", "Not connected to Kubernetes cluster", "Close")
+			m.showModal = true
+			return m, nil
+		}
+		rt := normalizeResourceTypeForFetch(msg.ResourceType)
+		nsSelection := ""
+		if namespaceWidget, ok := m.widgets[0].(*widgets.NameSpaceWidget); ok {
+			nsSelection = namespaceWidget.GetSelectedNameSpace()
+		}
+		if nsSelection == "" {
+			nsSelection = msg.Namespace
+		}
+		queryNamespace, _ := resolveNamespaceSelection(nsSelection)
+
+		if m.watching && m.watchResource == rt && m.watchNamespace == queryNamespace {
+			m.stopWatch()
+			return m, nil
+		}
+
+		m.stopWatch()
+		return m, m.startWatch(rt, queryNamespace)
+
+	case watchStartedMsg:
+		if msg.session != m.watchSession {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.stopWatch()
+			m.modal.ShowError("Watch Error", fmt.Sprintf("Failed to watch resources:\n%v", msg.err), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if mainContent, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
+			mainContent.UpdateResourcesOnly(resourceTableTitle(m.watchResource, m.watchNamespace, m.tableSelector), msg.resources)
+		}
+		return m, tea.Batch(waitForWatchEvent(msg.session, msg.events), ageTickCmd(msg.session))
+
+	case ageTickMsg:
+		// Returning is enough to re-render; the table computes ages as it draws
+		if msg.session != m.watchSession || !m.watching {
+			return m, nil
+		}
+		return m, ageTickCmd(msg.session)
+
+	case watchEventMsg:
+		if msg.session != m.watchSession {
+			return m, nil
+		}
+		if mainContent, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
+			switch msg.event.Type {
+			case kubernetes.WatchAdded, kubernetes.WatchModified:
+				mainContent.SetWatchError("")
+				mainContent.UpsertResource(msg.event.Resource)
+			case kubernetes.WatchDeleted:
+				mainContent.SetWatchError("")
+				mainContent.RemoveResource(msg.event.Resource.Namespace, msg.event.Resource.Name)
+			case kubernetes.WatchResync:
+				mainContent.SetWatchError("")
+				mainContent.UpdateResourcesOnly(resourceTableTitle(m.watchResource, m.watchNamespace, m.tableSelector), msg.event.Resources)
+			case kubernetes.WatchFailed:
+				// The watch retries in the background; until it delivers
+				// again the rows may be stale
+				if msg.event.Err != nil {
+					mainContent.SetWatchError(msg.event.Err.Error())
+				}
+			}
+		}
+		return m, waitForWatchEvent(msg.session, msg.events)
+
+	case watchClosedMsg:
+		if msg.session == m.watchSession {
+			m.stopWatch()
+		}
+		return m, nil
+
+	case widgets.BulkActionRequest:
+		if m.kubeClient == nil {
+			m.modal.ShowError("No Connection", "Not connected to Kubernetes cluster", "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if m.readOnly() {
+			m.modal.ShowError("Read-only Mode", "Bulk actions are disabled because l8zykube was started with --readonly", "Close")
+			m.showModal = true
+			return m, nil
+		}
+		m.bulkTargets = msg.Resources
+		m.menuModal.Show(fmt.Sprintf("Bulk Action (%d resources)", len(msg.Resources)), bulkMenuItems())
+		m.menuModal.SetDimensions(m.width, m.height)
+		m.showMenuModal = true
+		m.menuAction = menuBulkAction
+		return m, nil
+
+	case bulkFinishedMsg:
+		m.resultsModal.Show(msg.action, msg.results)
+		m.resultsModal.SetDimensions(m.width, m.height)
+		m.showResultsModal = true
+		if mainContent, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
+			mainContent.ClearMarks()
 		}
-	}
+		return m, m.refreshTable()
 
-	switch msg := msg.(type) {
-	case widgets.ShowLogsRequest:
+	case widgets.ScaleResourceRequest:
 		if m.kubeClient == nil {
 			m.modal.ShowError("No Connection", "Not connected to Kubernetes cluster", "Close")
 			m.showModal = true
 			return m, nil
 		}
-		if strings.EqualFold(msg.Resource.Type, "Pod") || strings.EqualFold(msg.Resource.Type, "Pods") {
-			logs, err := m.kubeClient.GetPodLogs(msg.Resource.Namespace, msg.Resource.Name, 1000)
-			if err != nil {
-				m.modal.ShowError("Logs Error", fmt.Sprintf("Failed to get logs:\n%v", err), "Close")
-				m.showModal = true
-				return m, nil
-			}
-			logLineCount := len(strings.Split(logs, "\n"))
-			m.logsModal.Show(fmt.Sprintf("Pod Logs: %s (namespace: %s) - %d lines", msg.Resource.Name, msg.Resource.Namespace, logLineCount), logs)
-			m.logsModal.SetDimensions(m.width, m.height)
-			m.showLogsModal = true
+		if m.readOnly() {
+			m.modal.ShowError("Read-only Mode", "Scaling is disabled because l8zykube was started with --readonly", "Close")
+			m.showModal = true
 			return m, nil
 		}
-		m.modal.ShowError("No Pod Selected", "Please select a pod to view logs", "Close")
-		m.showModal = true
+		ctx, id := m.requests.Start(requestScale)
+		return m, tea.Batch(
+			m.widgets[2].SetLoading(fmt.Sprintf("Loading replicas of %s...", msg.Resource.Name)),
+			loadReplicasCmd(ctx, m.kubeClient, id, msg.Resource),
+		)
+
+	case replicasLoadedMsg:
+		if !m.finishRequest(requestScale, msg.id) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.modal.ShowError("Scale Error", msg.err.Error(), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		m.scaleTarget = msg.resource
+		current := strconv.Itoa(int(msg.replicas))
+		m.promptModal.Show(fmt.Sprintf("Scale %s", bulkTarget(msg.resource)), fmt.Sprintf("Replica count (currently %s)", current), current, current)
+		m.promptModal.SetDimensions(m.width, m.height)
+		m.showPromptModal = true
+		m.promptAction = promptScale
 		return m, nil
 
-	case widgets.ToggleWatchRequest:
+	case resourceScaledMsg:
+		if msg.err != nil {
+			m.modal.ShowError("Scale Error", msg.err.Error(), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		// A running watch already shows the replicas converging
+		if m.watching {
+			return m, nil
+		}
+		return m, m.refreshTable()
+
+	case widgets.RolloutRequest:
 		if m.kubeClient == nil {
 			m.modal.ShowError("No Connection", "Not connected to Kubernetes cluster", "Close")
 			m.showModal = true
 			return m, nil
 		}
-		rt := normalizeResourceTypeForFetch(msg.ResourceType)
-		nsSelection := ""
-		if namespaceWidget, ok := m.widgets[0].(*widgets.NameSpaceWidget); ok {
-			nsSelection = namespaceWidget.GetSelectedNameSpace()
+		resourceType := normalizeResourceTypeForFetch(msg.Resource.Type)
+		if !kubernetes.IsRolloutResource(resourceType) {
+			m.modal.ShowError("Rollout", fmt.Sprintf("%s have no rollouts; only deployments, statefulsets and daemonsets do", resourceType), "Close")
+			m.showModal = true
+			return m, nil
 		}
-		if nsSelection == "" {
-			nsSelection = msg.Namespace
+		m.rolloutTarget = msg.Resource
+		m.menuModal.Show(fmt.Sprintf("Rollout: %s", bulkTarget(msg.Resource)), rolloutMenuItems(resourceType, m.readOnly()))
+		m.menuModal.SetDimensions(m.width, m.height)
+		m.showMenuModal = true
+		m.menuAction = menuRollout
+		return m, nil
+
+	case rolloutHistoryLoadedMsg:
+		if !m.finishRequest(requestRollout, msg.id) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.modal.ShowError("Rollout Error", msg.err.Error(), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if len(msg.revisions) == 0 {
+			m.modal.ShowInfo("Rollout History", fmt.Sprintf("%s has no recorded revisions", bulkTarget(msg.resource)))
+			m.showModal = true
+			return m, nil
+		}
+		m.rolloutTarget = msg.resource
+		title := fmt.Sprintf("History: %s - enter: roll back", bulkTarget(msg.resource))
+		if m.readOnly() {
+			title = fmt.Sprintf("History: %s", bulkTarget(msg.resource))
 		}
-		queryNamespace, displayNamespace := resolveNamespaceSelection(nsSelection)
+		m.menuModal.Show(title, rolloutHistoryMenuItems(msg.revisions))
+		m.menuModal.SetDimensions(m.width, m.height)
+		m.showMenuModal = true
+		m.menuAction = menuRolloutRevision
+		return m, nil
 
-		if m.watching && m.watchResource == rt && m.watchNamespace == queryNamespace {
-			m.watching = false
-			if mainContent, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
-				mainContent.SetWatching(false)
-			}
-			m.watchNamespace = ""
+	case rolloutFinishedMsg:
+		if msg.err != nil {
+			m.modal.ShowError(msg.action+" Error", msg.err.Error(), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if m.watching {
 			return m, nil
 		}
+		return m, m.refreshTable()
 
-		m.watching = true
-		m.watchResource = rt
-		m.watchNamespace = queryNamespace
+	case widgets.LabelSelectorRequest:
+		return m.applySelector(m.tableSelector.WithLabel(msg.Selector))
 
-		if resources, err := m.kubeClient.GetResourceListDetailed(rt, queryNamespace); err == nil {
-			if mainContent, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
-				mainContent.UpdateResourcesOnly(fmt.Sprintf("%s in %s", rt, displayNamespace), resources)
-				mainContent.SetWatching(true)
-			}
+	case widgets.DeleteResourceRequest:
+		if m.kubeClient == nil {
+			m.modal.ShowError("No Connection", "Not connected to Kubernetes cluster", "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if m.readOnly() {
+			m.modal.ShowError("Read-only Mode", "Deleting is disabled because l8zykube was started with --readonly", "Close")
+			m.showModal = true
+			return m, nil
 		}
-		return m, watchTickCmd()
+		res := msg.Resource
+		m.deleteTarget = &res
+		m.deleteOptions = kubernetes.DeleteOptions{}
+		client := m.kubeClient
+		m.modal.ShowConfirm("Delete Resource", deleteConfirmMessage(res, m.deleteOptions), func() {
+			m.pending.Add(deleteResourceCmd(client, res, kubernetes.DeleteOptions{}))
+		}, nil)
+		m.modal.Type = components.ModalWarning
+		m.showModal = true
+		return m, nil
 
-	case WatchTick:
-		if !m.watching || m.kubeClient == nil {
+	case resourceDeletedMsg:
+		target := fmt.Sprintf("%s/%s", normalizeResourceTypeForFetch(msg.resource.Type), msg.resource.Name)
+		if msg.err != nil {
+			m.modal.ShowError("Delete Error", fmt.Sprintf("Failed to delete %s:\n%v", target, msg.err), "Close")
+			m.showModal = true
 			return m, nil
 		}
-		if resources, err := m.kubeClient.GetResourceListDetailed(m.watchResource, m.watchNamespace); err == nil {
-			if mainContent, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
-				displayNamespace := namespaceDisplayFromQuery(m.watchNamespace)
-				mainContent.UpdateResourcesOnly(fmt.Sprintf("%s in %s", m.watchResource, displayNamespace), resources)
+		m.modal.ShowSuccess("Resource Deleted", fmt.Sprintf("Deleted %s", target))
+		m.showModal = true
+		return m, m.refreshTable()
+
+	case createEditorFinishedMsg:
+		defer os.Remove(msg.path)
+		cmds := []tea.Cmd{tea.EnterAltScreen}
+		if msg.err != nil {
+			m.modal.ShowError("Create Error", fmt.Sprintf("Editor exited with an error: %v", msg.err), "Close")
+			m.showModal = true
+			return m, tea.Batch(cmds...)
+		}
+		data, err := os.ReadFile(msg.path)
+		if err != nil {
+			m.modal.ShowError("Create Error", fmt.Sprintf("Failed to read edited manifest:\n%v", err), "Close")
+			m.showModal = true
+			return m, tea.Batch(cmds...)
+		}
+		if isBlankManifest(string(data)) {
+			m.modal.ShowInfo("Create Cancelled", "The manifest was empty, nothing was created")
+			m.showModal = true
+			return m, tea.Batch(cmds...)
+		}
+		if m.kubeClient == nil {
+			m.modal.ShowError("No Connection", "Not connected to Kubernetes cluster", "Close")
+			m.showModal = true
+			return m, tea.Batch(cmds...)
+		}
+		cmds = append(cmds, applyManifestCmd(m.kubeClient, data, m.selectedNamespaceForCreate()))
+		return m, tea.Batch(cmds...)
+
+	case manifestAppliedMsg:
+		if msg.err != nil {
+			message := fmt.Sprintf("Failed to apply manifest:\n%v", msg.err)
+			if len(msg.applied) > 0 {
+				message = fmt.Sprintf("%s\n\nApplied before the error:\n%s", message, strings.Join(msg.applied, "\n"))
 			}
+			m.modal.ShowError("Create Error", message, "Close")
+			m.showModal = true
+			return m, nil
 		}
-		return m, watchTickCmd()
+		m.modal.ShowSuccess("Resources Applied", strings.Join(msg.applied, "\n"))
+		m.showModal = true
+		return m, m.refreshTable()
 
 	case widgets.ShowDescribeRequest:
 		if m.kubeClient == nil {
@@ -588,58 +2105,233 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			rt = "deployments"
 		}
 		namespace := strings.TrimSpace(msg.Resource.Namespace)
-		displayNamespace := namespace
-		if displayNamespace == "" {
-			displayNamespace = "default"
+		return m, m.loadDescribe(rt, namespace, msg.Resource.Name)
+
+	case editObjectLoadedMsg:
+		if msg.err != nil {
+			return m.finishEdit("Edit Error", msg.err)
+		}
+		m.editObject = msg.object
+		manifest := msg.object.Manifest
+		if msg.edited != nil {
+			manifest = msg.edited
+		}
+		return m.reopenEditor(manifest, msg.failure)
+
+	case editEditorFinishedMsg:
+		defer os.Remove(msg.path)
+		if msg.err != nil {
+			next, cmd := m.finishEdit("Edit Error", fmt.Errorf("editor exited with an error: %v", msg.err))
+			return next, tea.Batch(tea.EnterAltScreen, cmd)
 		}
-		desc, err := m.kubeClient.DescribeResource(rt, namespace, msg.Resource.Name)
+		data, err := os.ReadFile(msg.path)
 		if err != nil {
-			m.modal.ShowError("Describe Error", fmt.Sprintf("Failed to describe resource:\n%v", err), "Close")
+			next, cmd := m.finishEdit("Edit Error", fmt.Errorf("failed to read the edited object: %v", err))
+			return next, tea.Batch(tea.EnterAltScreen, cmd)
+		}
+		if isBlankManifest(string(data)) {
+			m.modal.ShowInfo("Edit Cancelled", "The edited file was empty, nothing was changed")
+			m.showModal = true
+			next, cmd := m.finishEdit("", nil)
+			return next, tea.Batch(tea.EnterAltScreen, cmd)
+		}
+		return m, tea.Batch(tea.EnterAltScreen, dryRunEditCmd(m.kubeClient, m.editObject, data))
+
+	case editDryRunMsg:
+		if msg.err != nil {
+			return m.retryEdit(msg.edited, msg.err)
+		}
+		if msg.diff == "" {
+			m.modal.ShowInfo("Edit Cancelled", "No changes were made")
 			m.showModal = true
+			return m.finishEdit("", nil)
+		}
+		m.editDraft = msg.edited
+		m.diffModal.Show(fmt.Sprintf("Apply changes to %s/%s?", m.editObject.ResourceType, m.editObject.Name), msg.diff)
+		m.diffModal.SetDimensions(m.width, m.height)
+		m.showDiffModal = true
+		return m, nil
+
+	case editAppliedMsg:
+		if msg.err != nil {
+			return m.retryEdit(msg.edited, msg.err)
+		}
+		if msg.changed {
+			m.modal.ShowSuccess("Resource Edited", fmt.Sprintf("Updated %s/%s", m.editObject.ResourceType, m.editObject.Name))
+		} else {
+			m.modal.ShowInfo("Edit Cancelled", "No changes were made")
+		}
+		m.showModal = true
+		return m.finishEdit("", nil)
+
+	case spinner.TickMsg:
+		var cmds []tea.Cmd
+		for _, w := range m.widgets {
+			cmds = append(cmds, w.UpdateSpinner(msg))
+		}
+		return m, tea.Batch(cmds...)
+
+	case apiResourcesLoadedMsg:
+		if !m.finishRequest(requestAPIResources, msg.id) {
 			return m, nil
 		}
-		title := fmt.Sprintf("Describe: %s/%s (namespace: %s)", rt, msg.Resource.Name, displayNamespace)
-		m.describeModal.Show(title, desc, rt, namespace, msg.Resource.Name)
-		m.describeModal.SetDimensions(m.width, m.height)
-		m.describeModal.SetMode(components.DescribeModeRead)
-		m.showDescribeModal = true
-		m.showLogsModal = false
-		m.runningKubectlEdit = false
+		if msg.err != nil {
+			m.modal.ShowError("Kubernetes Connection Failed", fmt.Sprintf("Failed to load API resources:\n%v\n\nPlease check your kubeconfig and cluster status.", msg.err), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if apiResourceWidget, ok := m.widgets[1].(*widgets.ApiResourceWidget); ok {
+			apiResourceWidget.SetApiResourceList(msg.resources)
+		}
 		return m, nil
 
-	case kubectlEditFinishedMsg:
-		m.runningKubectlEdit = false
-		cmds := []tea.Cmd{tea.EnterAltScreen}
+	case namespacesLoadedMsg:
+		if !m.finishRequest(requestNamespaces, msg.id) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.modal.ShowError("Namespaces Error", fmt.Sprintf("Failed to list namespaces:\n%v", msg.err), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if mainContent, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
+			mainContent.SetNamespaceList(msg.namespaces)
+		}
+		return m, nil
 
-		if m.showDescribeModal {
-			rt, namespace, name := m.describeModal.TargetInfo()
-			m.describeModal.SetMode(components.DescribeModeRead)
-			if msg.err != nil {
-				m.modal.ShowError("kubectl edit", fmt.Sprintf("kubectl edit failed: %v", msg.err), "Close")
-				m.showModal = true
-				m.showDescribeModal = true
-				return m, tea.Batch(cmds...)
+	case resourcesLoadedMsg:
+		if !m.finishRequest(requestResources, msg.id) {
+			return m, nil
+		}
+		displayNS := namespaceDisplayFromQuery(msg.namespace)
+		if msg.err != nil {
+			m.modal.ShowError("Resources Error", fmt.Sprintf("Failed to list %s in %s:\n%v", msg.resourceType, displayNS, msg.err), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if mainContent, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
+			title := resourceTableTitle(msg.resourceType, msg.namespace, msg.selector)
+			if msg.refresh {
+				mainContent.UpdateResourcesOnly(title, msg.resources)
+			} else {
+				mainContent.SetResourcesDetailed(title, msg.resources)
 			}
-			if m.kubeClient != nil && m.describeModal.CanEdit() {
-				desc, err := m.kubeClient.DescribeResource(rt, namespace, name)
-				if err != nil {
-					m.modal.ShowError("Describe Error", fmt.Sprintf("Failed to refresh describe:\n%v", err), "Close")
-					m.showModal = true
-					m.showDescribeModal = true
-					return m, tea.Batch(cmds...)
-				}
-				title := fmt.Sprintf("Describe: %s/%s (namespace: %s)", rt, name, func() string {
-					if strings.TrimSpace(namespace) == "" {
-						return "default"
-					}
-					return namespace
-				}())
-				m.describeModal.Show(title, desc, rt, namespace, name)
-				m.describeModal.SetDimensions(m.width, m.height)
+			if m.jumpTarget.Name != "" && m.jumpTarget.Type == msg.resourceType {
+				mainContent.SelectResource(m.jumpTarget.Namespace, m.jumpTarget.Name)
+				m.jumpTarget = kubernetes.ResourceInfo{}
 			}
 		}
+		return m, nil
+
+	case exportSavedMsg:
+		if msg.err != nil {
+			m.modal.ShowError("Save Error", msg.err.Error(), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		notice := fmt.Sprintf("Saved to %s", msg.path)
+		if m.showLogsModal {
+			m.logsModal.SetNotice(notice)
+		} else if m.showDescribeModal {
+			m.describeModal.SetNotice(notice)
+		} else {
+			m.modal.ShowSuccess("Saved", msg.path)
+			m.showModal = true
+		}
+		return m, nil
+
+	case podContainersLoadedMsg:
+		if !m.finishRequest(requestLogs, msg.id) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.modal.ShowError("Logs Error", fmt.Sprintf("Failed to get containers:\n%v", msg.err), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		if len(msg.containers) == 0 {
+			m.modal.ShowError("Logs Error", fmt.Sprintf("Pod %s has no containers", msg.resource.Name), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		m.logContainers = msg.containers
+		if len(msg.containers) == 1 {
+			m.logOptions.Container = msg.containers[0].Name
+			return m.openLogs()
+		}
+		m.menuModal.Show(fmt.Sprintf("Logs: %s - select container", msg.resource.Name), containerMenuItems(msg.containers))
+		m.menuModal.SetDimensions(m.width, m.height)
+		m.showMenuModal = true
+		m.menuAction = menuLogContainer
+		return m, nil
+
+	case podLogsLoadedMsg:
+		if !m.finishRequest(requestLogs, msg.id) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.logsModal.SetStatus(logStatus(m.logOptions, m.logContainers))
+			m.modal.ShowError("Logs Error", fmt.Sprintf("Failed to get logs:\n%v", msg.err), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		m.logsModal.Show(m.logsTitle(), msg.logs)
+		m.logsModal.SetStatus(logStatus(m.logOptions, m.logContainers))
+		m.logsModal.SetDimensions(m.width, m.height)
+		m.logsModal.Title = fmt.Sprintf("%s - %d lines", m.logsTitle(), m.logsModal.LineCount())
+		m.showLogsModal = true
+		return m, nil
+
+	case logStreamStartedMsg:
+		if msg.session != m.logSession {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.stopLogStream()
+			m.modal.ShowError("Logs Error", fmt.Sprintf("Failed to follow logs:\n%v", msg.err), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		return m, waitForLogLines(msg.session, msg.lines)
+
+	case logLinesMsg:
+		if msg.session != m.logSession {
+			return m, nil
+		}
+		m.logsModal.AppendLines(msg.lines)
+		if msg.err != nil {
+			m.logsModal.AppendLines([]string{fmt.Sprintf("--- %v ---", msg.err)})
+		}
+		return m, waitForLogLines(msg.session, msg.stream)
+
+	case logStreamClosedMsg:
+		if msg.session == m.logSession {
+			m.logsModal.AppendLines([]string{"--- log stream closed ---"})
+			m.stopLogStream()
+		}
+		return m, nil
+
+	case describeLoadedMsg:
+		if !m.finishRequest(requestDescribe, msg.id) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.modal.ShowError("Describe Error", fmt.Sprintf("Failed to describe resource:\n%v", msg.err), "Close")
+			m.showModal = true
+			return m, nil
+		}
+		displayNamespace := msg.namespace
+		if displayNamespace == "" {
+			displayNamespace = "default"
+		}
+		title := fmt.Sprintf("Describe: %s/%s (namespace: %s)", msg.resourceType, msg.name, displayNamespace)
+		m.describeModal.Show(title, msg.description, msg.yaml, msg.resourceType, msg.namespace, msg.name)
+		m.describeModal.SetDimensions(m.width, m.height)
+		m.describeModal.SetMode(components.DescribeModeRead)
 		m.showDescribeModal = true
-		return m, tea.Batch(cmds...)
+		m.showLogsModal = false
+		m.resourceEditActive = false
+		return m, nil
 	}
 
 	return m, nil
@@ -648,7 +2340,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m MainModel) View() string {
 	namespaceWidth := 30
 	apiResourceWidth := 30
-	apiResourceHeight := m.height - 8
+	apiResourceHeight := m.height - 13
 	mainContentWidth := m.width - namespaceWidth - 4
 	mainContentHeight := m.height - 3
 
@@ -662,9 +2354,74 @@ func (m MainModel) View() string {
 		mainContentWidget.SetDimensions(mainContentWidth, mainContentHeight)
 	}
 
-	vertical := lipgloss.JoinVertical(lipgloss.Top, m.widgets[0].View(), m.widgets[1].View())
+	vertical := lipgloss.JoinVertical(lipgloss.Top, m.widgets[3].View(), m.widgets[0].View(), m.widgets[1].View())
 	horizontal := lipgloss.JoinHorizontal(lipgloss.Top, vertical, m.widgets[2].View())
 
+	if m.showModal {
+		modalContent := m.modal.Render()
+		modalStyle := lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height).
+			Align(lipgloss.Center, lipgloss.Center)
+
+		overlay := modalStyle.Render(modalContent)
+		return overlay
+	}
+
+	if m.showResultsModal {
+		resultsStyle := lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height).
+			Align(lipgloss.Center, lipgloss.Center)
+
+		return resultsStyle.Render(m.resultsModal.Render())
+	}
+
+	if m.showDiffModal {
+		diffStyle := lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height).
+			Align(lipgloss.Center, lipgloss.Center)
+
+		return diffStyle.Render(m.diffModal.Render())
+	}
+
+	if m.showForwardsPanel {
+		forwardsStyle := lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height).
+			Align(lipgloss.Center, lipgloss.Center)
+
+		return forwardsStyle.Render(m.forwardsPanel.Render())
+	}
+
+	if m.showEventsPanel {
+		eventsStyle := lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height).
+			Align(lipgloss.Center, lipgloss.Center)
+
+		return eventsStyle.Render(m.eventsPanel.Render())
+	}
+
+	if m.showPromptModal {
+		promptStyle := lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height).
+			Align(lipgloss.Center, lipgloss.Center)
+
+		return promptStyle.Render(m.promptModal.Render())
+	}
+
+	if m.showMenuModal {
+		menuStyle := lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height).
+			Align(lipgloss.Center, lipgloss.Center)
+
+		return menuStyle.Render(m.menuModal.Render())
+	}
+
 	if m.showDescribeModal {
 		descContent := m.describeModal.Render()
 		descStyle := lipgloss.NewStyle().
@@ -687,17 +2444,6 @@ func (m MainModel) View() string {
 		return overlay
 	}
 
-	if m.showModal {
-		modalContent := m.modal.Render()
-		modalStyle := lipgloss.NewStyle().
-			Width(m.width).
-			Height(m.height).
-			Align(lipgloss.Center, lipgloss.Center)
-
-		overlay := modalStyle.Render(modalContent)
-		return overlay
-	}
-
 	footer := m.renderFooter()
 	bodyWithFooter := lipgloss.JoinVertical(lipgloss.Left, horizontal, footer)
 	return bodyWithFooter
@@ -723,13 +2469,17 @@ func (m MainModel) renderFooter() string {
 				"↑/↓, j/k: scroll",
 				"pgup/pgdown: page",
 				"g/G, home/end: jump",
-				"ctrl+e: edit",
+			)
+			if !m.readOnly() {
+				hints = append(hints, "ctrl+e: edit")
+			}
+			hints = append(hints,
 				"esc: close",
 				"q: quit",
 			)
 		} else {
 			hints = append(hints,
-				"kubectl edit running",
+				"editor running",
 				"esc: close",
 				"q: quit",
 			)
@@ -742,12 +2492,27 @@ func (m MainModel) renderFooter() string {
 			"up/down, j/k: scroll",
 			"pgup/pgdown: page",
 			"g/G, home/end: jump",
+		)
+		if len(m.logContainers) > 1 {
+			hints = append(hints, "c: container")
+		}
+		hints = append(hints,
+			"/: search",
+			"&: filter",
+			"f: follow",
+			"p: previous",
+			"t: timestamps",
+			"s/S: since",
 			"esc: close",
 			"q: quit",
 		)
 		return style.Render(strings.Join(hints, "  |  "))
 	}
 
+	if m.requests.Pending() {
+		hints = append(hints, "esc: cancel loading")
+	}
+
 	switch m.focusedWidget {
 	case 0:
 		hints = append(hints, "j/k: move focus", "enter: choose namespace", "q: quit")
@@ -761,19 +2526,31 @@ func (m MainModel) renderFooter() string {
 		if mcw, ok := m.widgets[2].(*widgets.MainContentWidget); ok {
 			if mcw.SelectionNameSpace {
 				hints = append(hints, "j/k: move", "enter: select namespace", "esc: cancel", "q: quit")
+			} else if mcw.SelectionContext {
+				hints = append(hints, "j/k: move", "enter: switch context", "esc: cancel", "q: quit")
+			} else if mcw.IsLabelsPanelOpen() {
+				hints = append(hints, "j/k: move", "enter: list this kind by label", "esc: close labels")
 			} else if mcw.IsResourcesActive() {
 				hints = append(hints, "j/k, up/down: scroll", "esc: exit")
-				hints = append(hints, "ctrl+w: toggle watch")
+				hints = append(hints, "ctrl+w: toggle watch", "N/A/S/R/O: sort", "/: filter", "ctrl+f: selector", "L: labels column", "i: labels", "space/ctrl+a: mark", "b: bulk actions", "ctrl+s: scale", "ctrl+r: rollout", "p: port-forward", "ctrl+p: forwards", "ctrl+g: events")
 				if sel := mcw.GetSelectedResource(); sel != nil {
 					if sel.Type == "Pod" {
 						hints = append(hints, "ctrl+l: view logs")
+						if !m.readOnly() {
+							hints = append(hints, "s: shell")
+						}
 					}
 					hints = append(hints, "ctrl+d: describe resource")
+					if !m.readOnly() {
+						hints = append(hints, "ctrl+x: delete")
+					}
 				}
 			} else {
 				hints = append(hints, "enter: activate list", "j/k: move focus", "q: quit")
 			}
 		}
+	case 3:
+		hints = append(hints, "j/k: move focus", "enter: switch context", "q: quit")
 	default:
 		hints = append(hints, "j/k: move focus", "q: quit")
 	}
@@ -782,7 +2559,15 @@ func (m MainModel) renderFooter() string {
 }
 
 func main() {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
+	var opts kubernetes.ClientOptions
+	flag.StringVar(&opts.Kubeconfig, "kubeconfig", "", "path to the kubeconfig file (defaults to $KUBECONFIG, then ~/.kube/config)")
+	flag.StringVar(&opts.Context, "context", "", "kubeconfig context to use (defaults to the current context)")
+	flag.StringVar(&opts.Namespace, "namespace", "", "namespace to start in (defaults to the context namespace)")
+	flag.StringVar(&opts.Namespace, "n", "", "shorthand for --namespace")
+	flag.BoolVar(&opts.ReadOnly, "readonly", false, "disable every action that modifies the cluster")
+	flag.Parse()
+
+	p := tea.NewProgram(initialModel(opts), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v", err)
 	}
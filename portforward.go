@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"l8zykube/components"
+	"l8zykube/kubernetes"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// forwardsTickInterval is how often the port-forwards panel redraws its
+// byte counters
+const forwardsTickInterval = time.Second
+
+// forwardResources lists the resources port-forwards can be started on
+var forwardResources = map[string]bool{
+	"pods":        true,
+	"services":    true,
+	"deployments": true,
+}
+
+type forwardPortsLoadedMsg struct {
+	id       int
+	resource kubernetes.ResourceInfo
+	ports    []kubernetes.ForwardPort
+	err      error
+}
+
+type portForwardStartedMsg struct {
+	client  *kubernetes.KubeClient
+	forward *kubernetes.PortForward
+	err     error
+}
+
+type forwardsTickMsg struct{}
+
+func forwardsTickCmd() tea.Cmd {
+	return tea.Tick(forwardsTickInterval, func(time.Time) tea.Msg { return forwardsTickMsg{} })
+}
+
+func loadForwardPortsCmd(ctx context.Context, client *kubernetes.KubeClient, id int, res kubernetes.ResourceInfo) tea.Cmd {
+	return func() tea.Msg {
+		ports, err := client.ForwardablePorts(ctx, normalizeResourceTypeForFetch(res.Type), res.Namespace, res.Name)
+		return forwardPortsLoadedMsg{id: id, resource: res, ports: ports, err: err}
+	}
+}
+
+func startPortForwardCmd(client *kubernetes.KubeClient, res kubernetes.ResourceInfo, port kubernetes.ForwardPort, localPort int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		forward, err := client.StartPortForward(ctx, normalizeResourceTypeForFetch(res.Type), res.Namespace, res.Name, port, localPort)
+		return portForwardStartedMsg{client: client, forward: forward, err: err}
+	}
+}
+
+func forwardPortMenuItems(ports []kubernetes.ForwardPort) []components.MenuItem {
+	items := make([]components.MenuItem, 0, len(ports))
+	for i, p := range ports {
+		label := strconv.Itoa(int(p.Port))
+		if p.Name != "" {
+			label += " (" + p.Name + ")"
+		}
+		items = append(items, components.MenuItem{Label: label, Description: p.Protocol, Value: strconv.Itoa(i)})
+	}
+	return items
+}
+
+// parseForwardPorts parses "local", "local:remote" or ":remote" as typed
+// into the port-forward prompt. A missing remote port is defaultRemote; a
+// missing or zero local port lets the system pick one.
+func parseForwardPorts(value string, defaultRemote int32) (local int, remote int32, err error) {
+	localPart, remotePart, hasRemote := strings.Cut(strings.TrimSpace(value), ":")
+	remote = defaultRemote
+	if hasRemote {
+		r, err := strconv.ParseUint(strings.TrimSpace(remotePart), 10, 16)
+		if err != nil || r == 0 {
+			return 0, 0, fmt.Errorf("invalid remote port %q", remotePart)
+		}
+		remote = int32(r)
+	}
+	if remote == 0 {
+		return 0, 0, fmt.Errorf("no remote port given; use local:remote")
+	}
+	if localPart = strings.TrimSpace(localPart); localPart != "" {
+		l, err := strconv.ParseUint(localPart, 10, 16)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid local port %q", localPart)
+		}
+		local = int(l)
+	}
+	return local, remote, nil
+}
+
+// stopForward stops forward and removes it from forwards
+func stopForward(forwards []*kubernetes.PortForward, forward *kubernetes.PortForward) []*kubernetes.PortForward {
+	forward.Stop()
+	kept := make([]*kubernetes.PortForward, 0, len(forwards))
+	for _, f := range forwards {
+		if f != forward {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
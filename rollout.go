@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"l8zykube/components"
+	"l8zykube/kubernetes"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type rolloutHistoryLoadedMsg struct {
+	id        int
+	resource  kubernetes.ResourceInfo
+	revisions []kubernetes.RolloutRevision
+	err       error
+}
+
+type rolloutFinishedMsg struct {
+	action   string
+	resource kubernetes.ResourceInfo
+	err      error
+}
+
+// rolloutMenuItems lists the rollout actions of a workload; only deployments
+// can be paused. Read-only clients only get the history.
+func rolloutMenuItems(resourceType string, readOnly bool) []components.MenuItem {
+	if readOnly {
+		return []components.MenuItem{{Label: "History", Description: "List revisions", Value: "history"}}
+	}
+	items := []components.MenuItem{
+		{Label: "Restart", Description: "Roll out new pods with the same template", Value: "restart"},
+	}
+	if resourceType == "deployments" {
+		items = append(items,
+			components.MenuItem{Label: "Pause", Description: "Stop rolling out template changes", Value: "pause"},
+			components.MenuItem{Label: "Resume", Description: "Roll out template changes again", Value: "resume"},
+		)
+	}
+	return append(items, components.MenuItem{Label: "History / Undo", Description: "List revisions and roll back to one", Value: "history"})
+}
+
+// rolloutHistoryMenuItems lists revisions newest first
+func rolloutHistoryMenuItems(revisions []kubernetes.RolloutRevision) []components.MenuItem {
+	items := make([]components.MenuItem, 0, len(revisions))
+	for i := len(revisions) - 1; i >= 0; i-- {
+		rev := revisions[i]
+		label := fmt.Sprintf("Revision %d", rev.Revision)
+		if rev.Current {
+			label += " (current)"
+		}
+		cause := rev.ChangeCause
+		if cause == "" {
+			cause = "<none>"
+		}
+		items = append(items, components.MenuItem{
+			Label:       label,
+			Description: fmt.Sprintf("%s ago: %s", kubernetes.FormatAge(rev.CreatedAt), cause),
+			Value:       strconv.FormatInt(rev.Revision, 10),
+		})
+	}
+	return items
+}
+
+func loadRolloutHistoryCmd(ctx context.Context, client *kubernetes.KubeClient, id int, res kubernetes.ResourceInfo) tea.Cmd {
+	return func() tea.Msg {
+		revisions, err := client.RolloutHistory(ctx, normalizeResourceTypeForFetch(res.Type), res.Namespace, res.Name)
+		return rolloutHistoryLoadedMsg{id: id, resource: res, revisions: revisions, err: err}
+	}
+}
+
+// rolloutCmd runs a rollout action on res with its own timeout
+func rolloutCmd(action string, res kubernetes.ResourceInfo, fn func(ctx context.Context, resourceType string) error) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		return rolloutFinishedMsg{action: action, resource: res, err: fn(ctx, normalizeResourceTypeForFetch(res.Type))}
+	}
+}
+
+func rolloutRestartCmd(client *kubernetes.KubeClient, res kubernetes.ResourceInfo) tea.Cmd {
+	return rolloutCmd("Restart", res, func(ctx context.Context, resourceType string) error {
+		return client.RestartResource(ctx, resourceType, res.Namespace, res.Name)
+	})
+}
+
+func rolloutPauseCmd(client *kubernetes.KubeClient, res kubernetes.ResourceInfo, paused bool) tea.Cmd {
+	action := "Pause"
+	if !paused {
+		action = "Resume"
+	}
+	return rolloutCmd(action, res, func(ctx context.Context, resourceType string) error {
+		return client.SetRolloutPaused(ctx, resourceType, res.Namespace, res.Name, paused)
+	})
+}
+
+func rolloutUndoCmd(client *kubernetes.KubeClient, res kubernetes.ResourceInfo, revision int64) tea.Cmd {
+	return rolloutCmd("Undo", res, func(ctx context.Context, resourceType string) error {
+		return client.UndoRollout(ctx, resourceType, res.Namespace, res.Name, revision)
+	})
+}
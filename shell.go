@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"l8zykube/kubernetes"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/cancelreader"
+	"golang.org/x/term"
+	"k8s.io/client-go/util/exec"
+)
+
+// terminalResizeInterval is how often the terminal size is checked during a
+// shell session. Polling works the same on every platform, unlike SIGWINCH.
+const terminalResizeInterval = 250 * time.Millisecond
+
+type shellExitedMsg struct {
+	resource  kubernetes.ResourceInfo
+	container string
+	err       error
+}
+
+// shellCommand runs an interactive shell in a container. It implements
+// tea.ExecCommand, so bubbletea releases the terminal while it runs.
+type shellCommand struct {
+	client    *kubernetes.KubeClient
+	resource  kubernetes.ResourceInfo
+	container string
+	stdin     io.Reader
+	stdout    io.Writer
+}
+
+func (c *shellCommand) SetStdin(r io.Reader)  { c.stdin = r }
+func (c *shellCommand) SetStdout(w io.Writer) { c.stdout = w }
+func (c *shellCommand) SetStderr(io.Writer)   {}
+
+func (c *shellCommand) Run() error {
+	if c.stdin == nil {
+		c.stdin = os.Stdin
+	}
+	if c.stdout == nil {
+		c.stdout = os.Stdout
+	}
+
+	var sizes kubernetes.TerminalSizeQueue
+	if f, ok := c.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("failed to put the terminal into raw mode: %v", err)
+		}
+		defer term.Restore(fd, state)
+		resizes := newTerminalResizes(fd)
+		defer resizes.stop()
+		sizes = resizes
+	}
+
+	// A cancelable reader keeps the exec stream from swallowing the first key
+	// pressed after the shell exits
+	stdin, err := cancelreader.NewReader(c.stdin)
+	if err != nil {
+		return err
+	}
+	defer stdin.Cancel()
+
+	fmt.Fprintf(c.stdout, "Connecting to %s/%s, container %s...\r\n", c.resource.Namespace, c.resource.Name, c.container)
+	err = c.client.ExecShell(context.Background(), c.resource.Namespace, c.resource.Name, c.container, stdin, c.stdout, sizes)
+	// The shell's own exit code is not an error worth reporting
+	var exitErr exec.CodeExitError
+	if errors.As(err, &exitErr) {
+		return nil
+	}
+	return err
+}
+
+// shellCmd suspends the TUI and opens a shell in a container
+func shellCmd(client *kubernetes.KubeClient, res kubernetes.ResourceInfo, container string) tea.Cmd {
+	cmd := &shellCommand{client: client, resource: res, container: container}
+	return tea.Batch(
+		tea.ExitAltScreen,
+		tea.Exec(cmd, func(err error) tea.Msg {
+			return shellExitedMsg{resource: res, container: container, err: err}
+		}),
+	)
+}
+
+// terminalResizes reports the size of a terminal whenever it changes
+type terminalResizes struct {
+	sizes chan kubernetes.TerminalSize
+	done  chan struct{}
+}
+
+func newTerminalResizes(fd int) *terminalResizes {
+	r := &terminalResizes{
+		sizes: make(chan kubernetes.TerminalSize, 1),
+		done:  make(chan struct{}),
+	}
+	go func() {
+		defer close(r.sizes)
+		ticker := time.NewTicker(terminalResizeInterval)
+		defer ticker.Stop()
+		var last kubernetes.TerminalSize
+		for {
+			if width, height, err := term.GetSize(fd); err == nil {
+				size := kubernetes.TerminalSize{Width: uint16(width), Height: uint16(height)}
+				if size != last {
+					last = size
+					select {
+					case r.sizes <- size:
+					case <-r.done:
+						return
+					}
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-r.done:
+				return
+			}
+		}
+	}()
+	return r
+}
+
+// Next blocks until the terminal size changes; nil ends the session's resize
+// handling
+func (r *terminalResizes) Next() *kubernetes.TerminalSize {
+	size, ok := <-r.sizes
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+func (r *terminalResizes) stop() {
+	close(r.done)
+}
+
+// runningContainers keeps the containers a shell can be opened in
+func runningContainers(containers []kubernetes.ContainerInfo) []kubernetes.ContainerInfo {
+	var running []kubernetes.ContainerInfo
+	for _, c := range containers {
+		if c.State == "Running" {
+			running = append(running, c)
+		}
+	}
+	return running
+}
@@ -0,0 +1,30 @@
+package templates
+
+import (
+	"embed"
+	"fmt"
+)
+
+//go:embed *_template.yaml
+var files embed.FS
+
+// Catalog lists the built-in manifest templates in the order they are offered
+var Catalog = []string{
+	"pod",
+	"deployment",
+	"service",
+	"configmap",
+	"secret",
+	"job",
+	"cronjob",
+	"ingress",
+}
+
+// Get returns the YAML template for the given catalog name (e.g. "pod")
+func Get(name string) (string, error) {
+	data, err := files.ReadFile(name + "_template.yaml")
+	if err != nil {
+		return "", fmt.Errorf("unknown template: %s", name)
+	}
+	return string(data), nil
+}
@@ -185,7 +185,12 @@ func (a *ApiResourceWidget) View() string {
 	}
 
 	var content string
-	if len(a.ApiResourceList) == 0 {
+	if len(a.ApiResourceList) == 0 && a.loading {
+		content = lipgloss.NewStyle().
+			Align(lipgloss.Center).
+			Width(a.innerContentWidth()).
+			Render(a.loadingView())
+	} else if len(a.ApiResourceList) == 0 {
 		placeholderText := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("240")).
 			Align(lipgloss.Center).
@@ -194,11 +199,15 @@ func (a *ApiResourceWidget) View() string {
 
 		content = placeholderText
 	} else {
+		titleText := "API Resources"
+		if a.loading {
+			titleText = fmt.Sprintf("API Resources %s", a.spinner.View())
+		}
 		title := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("205")).
 			Bold(true).
 			MarginLeft(2).
-			Render("API Resources")
+			Render(titleText)
 
 		// Add search bar if search is active
 		searchBar := ""
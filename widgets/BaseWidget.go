@@ -1,6 +1,10 @@
 package widgets
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
 
 type Widget interface {
 	Update(msg tea.Msg) (Widget, tea.Cmd)
@@ -8,12 +12,19 @@ type Widget interface {
 	SetFocused(bool)
 	IsFocused() bool
 	SetDimensions(width, height int)
+	SetLoading(label string) tea.Cmd
+	ClearLoading()
+	IsLoading() bool
+	UpdateSpinner(msg spinner.TickMsg) tea.Cmd
 }
 
 type BaseWidget struct {
-	focused bool
-	width   int
-	height  int
+	focused      bool
+	width        int
+	height       int
+	loading      bool
+	loadingLabel string
+	spinner      spinner.Model
 }
 
 func (b *BaseWidget) SetFocused(focused bool) {
@@ -28,3 +39,43 @@ func (b *BaseWidget) SetDimensions(width, height int) {
 	b.width = width
 	b.height = height
 }
+
+// SetLoading shows a spinner with the given label while an API call for this
+// widget is in flight. The returned command starts the spinner animation.
+func (b *BaseWidget) SetLoading(label string) tea.Cmd {
+	if b.spinner.ID() == 0 {
+		b.spinner = spinner.New(spinner.WithSpinner(spinner.Dot))
+		b.spinner.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	}
+	b.loadingLabel = label
+	if b.loading {
+		// The spinner is already ticking
+		return nil
+	}
+	b.loading = true
+	return b.spinner.Tick
+}
+
+// ClearLoading hides the spinner once the API call has finished
+func (b *BaseWidget) ClearLoading() {
+	b.loading = false
+	b.loadingLabel = ""
+}
+
+func (b *BaseWidget) IsLoading() bool {
+	return b.loading
+}
+
+// UpdateSpinner advances the spinner; ticks belonging to other widgets' spinners are ignored
+func (b *BaseWidget) UpdateSpinner(msg spinner.TickMsg) tea.Cmd {
+	if !b.loading {
+		return nil
+	}
+	var cmd tea.Cmd
+	b.spinner, cmd = b.spinner.Update(msg)
+	return cmd
+}
+
+func (b *BaseWidget) loadingView() string {
+	return b.spinner.View() + " " + b.loadingLabel
+}
@@ -0,0 +1,64 @@
+package widgets
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ContextWidget struct {
+	BaseWidget
+	CurrentContext string
+	ReadOnly       bool
+}
+
+func NewContextWidget() *ContextWidget {
+	return &ContextWidget{
+		BaseWidget: BaseWidget{
+			focused: false,
+		},
+	}
+}
+
+func (c *ContextWidget) SetCurrentContext(contextName string) {
+	c.CurrentContext = contextName
+}
+
+func (c *ContextWidget) SetReadOnly(readOnly bool) {
+	c.ReadOnly = readOnly
+}
+
+func (c *ContextWidget) GetCurrentContext() string {
+	return c.CurrentContext
+}
+
+func (c *ContextWidget) Update(msg tea.Msg) (Widget, tea.Cmd) {
+	return c, nil
+}
+
+func (c *ContextWidget) View() string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(1, 2).
+		Width(30)
+
+	if c.focused {
+		style = style.BorderForeground(lipgloss.Color("205"))
+	} else {
+		style = style.BorderForeground(lipgloss.Color("240"))
+	}
+
+	contextName := c.CurrentContext
+	if contextName == "" {
+		contextName = "<none>"
+	}
+	label := "Context"
+	if c.ReadOnly {
+		// Keep the widget a single line tall so the left column layout is stable
+		label = "Context (RO)"
+	}
+	content := truncateWithEllipsis(fmt.Sprintf("%s: %s", label, contextName), 26)
+	return style.Render(content)
+}
@@ -11,8 +11,11 @@ import (
 type MainContentWidget struct {
 	BaseWidget
 	SelectionNameSpace bool
+	SelectionContext   bool
 	namespaceSelector  *components.NamespaceSelector
+	contextSelector    *components.ContextSelector
 	resourceTable      *components.ResourceTable
+	labelsPanel        *components.LabelsPanel
 	welcomeScreen      *components.WelcomeScreen
 }
 
@@ -24,18 +27,65 @@ type ShowDescribeRequest struct {
 	Resource kubetypes.ResourceInfo
 }
 
+type DeleteResourceRequest struct {
+	Resource kubetypes.ResourceInfo
+}
+
+// LabelSelectorRequest lists the current resource type again with Selector
+// as its label selector, keeping any field selector
+type LabelSelectorRequest struct {
+	Selector string
+}
+
+// BulkActionRequest opens the bulk action menu for the marked rows, or the
+// selected row when none is marked
+type BulkActionRequest struct {
+	Resources []kubetypes.ResourceInfo
+}
+
+// ScaleResourceRequest asks for a new replica count of Resource
+type ScaleResourceRequest struct {
+	Resource kubetypes.ResourceInfo
+}
+
+// RolloutRequest asks for the rollout actions of a workload
+type RolloutRequest struct {
+	Resource kubetypes.ResourceInfo
+}
+
+// ShellRequest asks to open a shell in a container of a pod
+type ShellRequest struct {
+	Resource kubetypes.ResourceInfo
+}
+
+// PortForwardRequest asks to forward a local port to Resource
+type PortForwardRequest struct {
+	Resource kubetypes.ResourceInfo
+}
+
 type ToggleWatchRequest struct {
 	ResourceType string
 	Namespace    string
 }
 
+// sortColumns maps the sort keys of the resource table to their columns
+var sortColumns = map[string]string{
+	"N": "NAME",
+	"A": "AGE",
+	"S": "STATUS",
+	"R": "RESTARTS",
+	"O": "NODE",
+}
+
 func NewMainContentWidget() *MainContentWidget {
 	return &MainContentWidget{
 		BaseWidget: BaseWidget{
 			focused: false,
 		},
 		namespaceSelector: components.NewNamespaceSelector(),
+		contextSelector:   components.NewContextSelector(),
 		resourceTable:     components.NewResourceTable(),
+		labelsPanel:       components.NewLabelsPanel(),
 		welcomeScreen:     components.NewWelcomeScreen(),
 	}
 }
@@ -53,21 +103,40 @@ func (m *MainContentWidget) Update(msg tea.Msg) (Widget, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Context selection works the same way as namespace selection
+	if m.SelectionContext {
+		cmd = m.contextSelector.Update(msg)
+		return m, cmd
+	}
+
 	// Default behavior when not in selection mode
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		key := msg.String()
+		if m.resourceTable.Filtering {
+			m.updateFilter(msg)
+			return m, nil
+		}
+		if m.labelsPanel.Visible {
+			return m, m.updateLabelsPanel(msg)
+		}
+
 		switch key {
 		case tea.KeyEnter.String():
-			if len(m.resourceTable.Resources) > 0 {
+			if m.resourceTable.Total() > 0 {
 				m.resourceTable.SetActive(true)
 			}
 
 		case tea.KeyEscape.String():
+			// The first esc drops the filter, the next one leaves the table
+			if m.resourceTable.Filter != "" {
+				m.resourceTable.SetFilter("")
+				return m, nil
+			}
 			m.resourceTable.SetActive(false)
 		}
 
-		if m.resourceTable.Active && len(m.resourceTable.Resources) > 0 {
+		if m.resourceTable.Active && m.resourceTable.Total() > 0 {
 			switch key {
 			case "down", "j":
 				m.resourceTable.ScrollDown()
@@ -81,6 +150,31 @@ func (m *MainContentWidget) Update(msg tea.Msg) (Widget, tea.Cmd) {
 				m.resourceTable.ScrollToTop()
 			case "end", "G":
 				m.resourceTable.ScrollToBottom()
+			case "N", "A", "S", "R", "O":
+				m.resourceTable.ToggleSort(sortColumns[key])
+			case "/":
+				m.resourceTable.Filtering = true
+			case " ":
+				m.resourceTable.ToggleMark()
+			case "ctrl+a":
+				m.resourceTable.ToggleMarkAll()
+			case "b":
+				targets := m.resourceTable.MarkedResources()
+				if len(targets) == 0 {
+					if sel := m.GetSelectedResource(); sel != nil {
+						targets = []kubetypes.ResourceInfo{*sel}
+					}
+				}
+				if len(targets) == 0 {
+					return m, nil
+				}
+				return m, func() tea.Msg { return BulkActionRequest{Resources: targets} }
+			case "L":
+				m.resourceTable.ShowLabels = !m.resourceTable.ShowLabels
+			case "i":
+				if sel := m.GetSelectedResource(); sel != nil {
+					m.labelsPanel.Show(*sel)
+				}
 			case "ctrl+l":
 				if sel := m.GetSelectedResource(); sel != nil {
 					res := *sel
@@ -93,6 +187,36 @@ func (m *MainContentWidget) Update(msg tea.Msg) (Widget, tea.Cmd) {
 					return m, func() tea.Msg { return ShowDescribeRequest{Resource: res} }
 				}
 				return m, nil
+			case "ctrl+x":
+				if sel := m.GetSelectedResource(); sel != nil {
+					res := *sel
+					return m, func() tea.Msg { return DeleteResourceRequest{Resource: res} }
+				}
+				return m, nil
+			case "ctrl+s":
+				if sel := m.GetSelectedResource(); sel != nil {
+					res := *sel
+					return m, func() tea.Msg { return ScaleResourceRequest{Resource: res} }
+				}
+				return m, nil
+			case "s":
+				if sel := m.GetSelectedResource(); sel != nil {
+					res := *sel
+					return m, func() tea.Msg { return ShellRequest{Resource: res} }
+				}
+				return m, nil
+			case "p":
+				if sel := m.GetSelectedResource(); sel != nil {
+					res := *sel
+					return m, func() tea.Msg { return PortForwardRequest{Resource: res} }
+				}
+				return m, nil
+			case "ctrl+r":
+				if sel := m.GetSelectedResource(); sel != nil {
+					res := *sel
+					return m, func() tea.Msg { return RolloutRequest{Resource: res} }
+				}
+				return m, nil
 			case "ctrl+w":
 				if sel := m.GetSelectedResource(); sel != nil {
 					res := *sel
@@ -105,6 +229,63 @@ func (m *MainContentWidget) Update(msg tea.Msg) (Widget, tea.Cmd) {
 	return m, nil
 }
 
+// updateFilter edits the table filter while it is being typed. Enter keeps
+// the filter, esc drops it.
+func (m *MainContentWidget) updateFilter(msg tea.KeyMsg) {
+	filter := m.resourceTable.Filter
+	switch msg.String() {
+	case tea.KeyEnter.String():
+		m.resourceTable.Filtering = false
+		return
+	case tea.KeyEscape.String():
+		m.resourceTable.Filtering = false
+		filter = ""
+	case "backspace", "ctrl+h":
+		if r := []rune(filter); len(r) > 0 {
+			filter = string(r[:len(r)-1])
+		}
+	case "up":
+		m.resourceTable.ScrollUp()
+		return
+	case "down":
+		m.resourceTable.ScrollDown()
+		return
+	default:
+		if msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace {
+			return
+		}
+		filter += string(msg.Runes)
+	}
+	m.resourceTable.SetFilter(filter)
+}
+
+// updateLabelsPanel moves through the labels panel; enter lists everything
+// carrying the selected label
+func (m *MainContentWidget) updateLabelsPanel(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		m.labelsPanel.MoveUp()
+	case "down", "j":
+		m.labelsPanel.MoveDown()
+	case tea.KeyEscape.String(), "i":
+		m.labelsPanel.Hide()
+	case tea.KeyEnter.String():
+		selector, ok := m.labelsPanel.SelectedLabel()
+		if !ok {
+			return nil
+		}
+		m.labelsPanel.Hide()
+		return func() tea.Msg { return LabelSelectorRequest{Selector: selector} }
+	}
+	return nil
+}
+
+// IsFilterInputActive reports whether the table filter is being typed, in
+// which case every key belongs to it
+func (m *MainContentWidget) IsFilterInputActive() bool {
+	return m.focused && m.resourceTable.Filtering
+}
+
 func (m *MainContentWidget) SetSelectionNameSpace(isSelection bool) {
 	m.SelectionNameSpace = isSelection
 }
@@ -117,9 +298,22 @@ func (m *MainContentWidget) GetSelectedNamespace() string {
 	return m.namespaceSelector.GetSelectedNamespace()
 }
 
+func (m *MainContentWidget) SetSelectionContext(isSelection bool) {
+	m.SelectionContext = isSelection
+}
+
+func (m *MainContentWidget) SetContextList(contexts []kubetypes.ContextInfo) {
+	m.contextSelector.SetContextList(contexts)
+}
+
+func (m *MainContentWidget) GetSelectedContext() string {
+	return m.contextSelector.GetSelectedContext()
+}
+
 func (m *MainContentWidget) SetDimensions(width, height int) {
 	m.BaseWidget.SetDimensions(width, height)
 	m.namespaceSelector.SetDimensions(width, height)
+	m.contextSelector.SetDimensions(width, height)
 	m.resourceTable.SetDimensions(width, height)
 	m.welcomeScreen.SetDimensions(width, height)
 }
@@ -148,11 +342,23 @@ func (m *MainContentWidget) View() string {
 		style = style.BorderForeground(lipgloss.Color("240"))
 	}
 
+	// Keep showing the current table while it reloads and surface the spinner
+	// in its title; anything else is replaced by the loading message.
+	m.resourceTable.SetStatus("")
 	var content string
-	if m.SelectionNameSpace {
+	if m.loading && m.resourceTable.Total() > 0 && !m.SelectionNameSpace && !m.SelectionContext {
+		m.resourceTable.SetStatus(m.loadingView())
+		content = m.renderTable()
+	} else if m.loading {
+		content = lipgloss.NewStyle().
+			MarginLeft(2).
+			Render(m.loadingView())
+	} else if m.SelectionNameSpace {
 		content = m.namespaceSelector.Render()
-	} else if len(m.resourceTable.Resources) > 0 {
-		content = m.resourceTable.Render()
+	} else if m.SelectionContext {
+		content = m.contextSelector.Render()
+	} else if m.resourceTable.Total() > 0 {
+		content = m.renderTable()
 	} else {
 		content = m.welcomeScreen.Render()
 	}
@@ -160,18 +366,74 @@ func (m *MainContentWidget) View() string {
 	return style.Render(content)
 }
 
+// labelsPanelWidth is the width taken from the table by the labels panel
+const labelsPanelWidth = 44
+
+// renderTable renders the resource table, with the labels panel to its right
+// when it is open
+func (m *MainContentWidget) renderTable() string {
+	if !m.labelsPanel.Visible || !m.resourceTable.Active {
+		m.labelsPanel.Hide()
+		m.resourceTable.SetDimensions(m.width, m.height)
+		return m.resourceTable.Render()
+	}
+	// The border and padding of the widget take 4 columns
+	tableWidth := m.width - 4 - labelsPanelWidth
+	m.resourceTable.SetDimensions(tableWidth, m.height)
+	m.labelsPanel.SetDimensions(labelsPanelWidth, m.height-4)
+	table := lipgloss.NewStyle().Width(tableWidth).Render(m.resourceTable.Render())
+	return lipgloss.JoinHorizontal(lipgloss.Top, table, m.labelsPanel.Render())
+}
+
+// IsLabelsPanelOpen reports whether the labels panel has the keyboard
+func (m *MainContentWidget) IsLabelsPanelOpen() bool {
+	return m.labelsPanel.Visible
+}
+
 func (m *MainContentWidget) SetResourcesDetailed(title string, resources []kubetypes.ResourceInfo) {
 	m.resourceTable.SetResources(title, resources)
 }
 
+func (m *MainContentWidget) ClearResources() {
+	m.resourceTable.SetResources("", []kubetypes.ResourceInfo{})
+}
+
 func (m *MainContentWidget) UpdateResourcesOnly(title string, resources []kubetypes.ResourceInfo) {
 	m.resourceTable.UpdateResourcesOnly(title, resources)
 }
 
+func (m *MainContentWidget) UpsertResource(resource kubetypes.ResourceInfo) {
+	m.resourceTable.UpsertResource(resource)
+}
+
+func (m *MainContentWidget) RemoveResource(namespace, name string) {
+	m.resourceTable.RemoveResource(namespace, name)
+}
+
+// SelectResource activates the table with the given row selected, if it
+// is listed
+func (m *MainContentWidget) SelectResource(namespace, name string) bool {
+	if !m.resourceTable.Select(namespace, name) {
+		return false
+	}
+	m.resourceTable.SetActive(true)
+	return true
+}
+
+func (m *MainContentWidget) ClearMarks() {
+	m.resourceTable.ClearMarks()
+}
+
 func (m *MainContentWidget) SetWatching(watching bool) {
 	m.resourceTable.SetWatching(watching)
 }
 
+// SetWatchError shows the error the running watch is retrying after; ""
+// clears it
+func (m *MainContentWidget) SetWatchError(err string) {
+	m.resourceTable.SetWatchError(err)
+}
+
 func (m *MainContentWidget) IsResourcesActive() bool {
 	return m.resourceTable.Active
 }